@@ -5,25 +5,43 @@ import (
 	"fmt"
 )
 
-// AddNode adds a node to the graph
+// AddNode adds a node to the graph. A node added to a reference is added to the referenced node
 func (n *Node) AddNode(parent string, newNode *Node) (*Node, error) {
+	return n.addNode(newIndex(n), parent, newNode)
+}
+
+// addNode adds a node to the graph whose index is given
+func (n *Node) addNode(x *index, parent string, newNode *Node) (*Node, error) {
 	if newNode == nil {
 		return nil, errors.NewIllegalArgumentError("newNode cannot be nil")
 	}
 	// the id must be unique
-	for _, node := range n.Traverse() {
-		if node.Id == newNode.Id {
-			return nil, errors.NewDuplicatedNodeError(fmt.Sprintf("duplicated ID %q", newNode.Id))
-		}
+	nodes := x.nodes
+	if _, found := nodes[newNode.Id]; found {
+		return nil, errors.NewDuplicatedNodeError(fmt.Sprintf("duplicated ID %q", newNode.Id))
+	}
+
+	parentNode, found := nodes[parent]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("parent %q not found", parent))
+	}
+	parentNode, err := resolve(parentNode, nodes)
+	if err != nil {
+		return nil, err
 	}
+	parentNode.Children = append(parentNode.Children, newNode)
+	x.add(parentNode, newNode)
 
-	nodes := n.Traverse()
-	for i, node := range nodes {
-		if node.Id == parent {
-			node.Children = append(node.Children, newNode)
-			nodes[i] = node
-			return n, nil
-		}
+	// the references of the new node must not create any cycle
+	err = checkReferences(n, newNode, nodes)
+	if err != nil {
+		parentNode.Children = parentNode.Children[:len(parentNode.Children)-1]
+		x.remove(newNode)
+		return nil, err
+	}
+	// only the references of the new node are out of sync
+	for _, node := range newNode.Traverse() {
+		x.syncReference(node)
 	}
-	return nil, errors.NewNodeNotFoundError(fmt.Sprintf("parent %q not found", parent))
+	return n, nil
 }
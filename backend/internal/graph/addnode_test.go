@@ -88,3 +88,37 @@ func TestNode_AddNode_FailsParentNotFound(t *testing.T) {
 		t.Errorf("The error message does not match. Expected \"duplicated ID \"id_G\"\", got %s", err)
 	}
 }
+
+func TestNode_AddNode_FailsReferenceCycle(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	d, err := root.FindNode("id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	node, err := graph.NewReference("id_K", d)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.AddNode("id_G", node)
+	if err == nil {
+		t.Errorf("AddNode did not return an error")
+		return
+	}
+	if err.Error() != "the references of the node \"id_K\" would create a cycle" {
+		t.Errorf("The error message does not match. Expected \"the references of the node \"id_K\" would create a cycle\", got %s", err)
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(g.Children) != 2 {
+		t.Errorf("The reference was added to node G")
+	}
+}
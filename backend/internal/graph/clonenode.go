@@ -0,0 +1,19 @@
+package graph
+
+// Clone returns a deep copy of this node
+func (n *Node) Clone() *Node {
+	clone := *n
+	if n.Properties != nil {
+		clone.Properties = make(map[string]string, len(n.Properties))
+		for key, value := range n.Properties {
+			clone.Properties[key] = value
+		}
+	}
+	if n.Children != nil {
+		clone.Children = make([]*Node, len(n.Children))
+		for i, child := range n.Children {
+			clone.Children[i] = child.Clone()
+		}
+	}
+	return &clone
+}
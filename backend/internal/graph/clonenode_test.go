@@ -0,0 +1,24 @@
+package graph_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNode_Clone_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	clone := root.Clone()
+	if !reflect.DeepEqual(root, clone) {
+		t.Errorf("The nodes do not match")
+		return
+	}
+	clone.Children[2].Children[0].Name = "X"
+	clone.SetProperty("p1", "X")
+	if root.Children[2].Children[0].Name != "F" || root.GetProperty("p1") != "abc" {
+		t.Errorf("The clone shares its nodes with the original")
+	}
+}
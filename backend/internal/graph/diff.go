@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeType is the type of a change between two graphs
+type ChangeType string
+
+const (
+	Added           = ChangeType("added")
+	Removed         = ChangeType("removed")
+	Moved           = ChangeType("moved")
+	Renamed         = ChangeType("renamed")
+	Retyped         = ChangeType("retyped")
+	Recolored       = ChangeType("recolored")
+	PropertyChanged = ChangeType("property")
+)
+
+const changeDescription = "~ %s %s: %s from %q to %q"
+
+// Change describes a change of a node between two graphs. Number is the outline number of the node, as assigned by
+// Stringify, in the graph containing it, i.e. the old graph for a removed node and the new one otherwise. From and To
+// hold the old and new values of the changed field, or the IDs of the old and new parents of a moved node
+type Change struct {
+	Type     ChangeType `json:"type"`
+	Id       string     `json:"id"`
+	Name     string     `json:"name"`
+	Number   string     `json:"number"`
+	Property string     `json:"property,omitempty"`
+	From     string     `json:"from,omitempty"`
+	To       string     `json:"to,omitempty"`
+}
+
+// Changes is the list of changes between two graphs
+type Changes []Change
+
+// Diff returns the changes turning the graph a into the graph b, matching the nodes by ID. Removed nodes are listed
+// first in the order of a, followed by the other changes in the order of b. The fields of references are not compared,
+// since they mirror the fields of the referenced nodes
+func Diff(a, b *Node) Changes {
+	oldNodes, oldParents, oldNumbers := diffIndex(a)
+	newNodes, newParents, newNumbers := diffIndex(b)
+	changes := make(Changes, 0)
+
+	for _, node := range a.Traverse() {
+		if _, found := newNodes[node.Id]; !found {
+			changes = append(changes, Change{Type: Removed, Id: node.Id, Name: node.Name, Number: oldNumbers[node.Id]})
+		}
+	}
+	for _, node := range b.Traverse() {
+		change := Change{Id: node.Id, Name: node.Name, Number: newNumbers[node.Id]}
+		old, found := oldNodes[node.Id]
+		if !found {
+			change.Type = Added
+			changes = append(changes, change)
+			continue
+		}
+		if oldParents[node.Id] != newParents[node.Id] {
+			changes = append(changes, change.with(Moved, "", oldParents[node.Id], newParents[node.Id]))
+		}
+		if old.IsReference() || node.IsReference() {
+			continue
+		}
+		if old.Name != node.Name {
+			changes = append(changes, change.with(Renamed, "", old.Name, node.Name))
+		}
+		if old.Type != node.Type {
+			changes = append(changes, change.with(Retyped, "", string(old.Type), string(node.Type)))
+		}
+		if old.Color != node.Color {
+			changes = append(changes, change.with(Recolored, "", old.Color, node.Color))
+		}
+		for _, key := range propertyKeys(old, node) {
+			if old.Properties[key] != node.Properties[key] {
+				changes = append(changes, change.with(PropertyChanged, key, old.Properties[key], node.Properties[key]))
+			}
+		}
+	}
+	return changes
+}
+
+// Stringify returns a human-readable representation of the changes, one per line, prefixed by "+" for added nodes,
+// "-" for removed nodes and "~" for changed nodes
+func (c Changes) Stringify() string {
+	var builder strings.Builder
+	for _, change := range c {
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// String returns a human-readable representation of the change
+func (c Change) String() string {
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("+ %s %s", c.Number, c.Name)
+	case Removed:
+		return fmt.Sprintf("- %s %s", c.Number, c.Name)
+	case PropertyChanged:
+		return fmt.Sprintf(changeDescription, c.Number, c.Name, fmt.Sprintf("property %q changed", c.Property),
+			c.From, c.To)
+	}
+	return fmt.Sprintf(changeDescription, c.Number, c.Name, c.Type, c.From, c.To)
+}
+
+// with returns a copy of the change with the given type and values
+func (c Change) with(changeType ChangeType, property, from, to string) Change {
+	c.Type = changeType
+	c.Property = property
+	c.From = from
+	c.To = to
+	return c
+}
+
+// diffIndex returns the nodes of the graph, the ID of the parent of each node and the outline number of each node
+func diffIndex(root *Node) (map[string]*Node, map[string]string, map[string]string) {
+	nodes := make(map[string]*Node)
+	parents := make(map[string]string)
+	for _, node := range root.Traverse() {
+		nodes[node.Id] = node
+		for _, child := range node.Children {
+			parents[child.Id] = node.Id
+		}
+	}
+	return nodes, parents, outlineNumbers(root)
+}
+
+// propertyKeys returns the sorted union of the property keys of both nodes
+func propertyKeys(a, b *Node) []string {
+	var keys []string
+	for key := range a.Properties {
+		keys = append(keys, key)
+	}
+	for key := range b.Properties {
+		if _, found := a.Properties[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
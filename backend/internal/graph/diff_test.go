@@ -0,0 +1,79 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"reflect"
+	"testing"
+)
+
+func TestDiff_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	changed := root.Clone()
+	_, err = changed.RemoveNode("0", "id_C")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = changed.MoveNode("id_D", "id_F", "id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	k, err := graph.NewLexeme("id_K", "K", red)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = changed.AddNode("id_G", k)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = changed.UpdateNode("id_D", &graph.Node{Id: "id_G", Name: "G2", Type: "division", Color: blu,
+		Properties: map[string]string{"p3": "abc"}})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	expected := graph.Changes{
+		{Type: graph.Removed, Id: "id_C", Name: "C", Number: "1.2"},
+		{Type: graph.Moved, Id: "id_F", Name: "F", Number: "1.1.1", From: "id_D", To: "id_B"},
+		{Type: graph.Renamed, Id: "id_G", Name: "G2", Number: "1.2.1", From: "G", To: "G2"},
+		{Type: graph.Retyped, Id: "id_G", Name: "G2", Number: "1.2.1", From: "lexeme", To: "division"},
+		{Type: graph.PropertyChanged, Id: "id_G", Name: "G2", Number: "1.2.1", Property: "p3", To: "abc"},
+		{Type: graph.Added, Id: "id_K", Name: "K", Number: "1.2.1.3"},
+	}
+	changes := graph.Diff(root, changed)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("The changes do not match. Expected %v, got %v", expected, changes)
+		return
+	}
+
+	text := `- 1.2 C
+~ 1.1.1 F: moved from "id_D" to "id_B"
+~ 1.2.1 G2: renamed from "G" to "G2"
+~ 1.2.1 G2: retyped from "lexeme" to "division"
+~ 1.2.1 G2: property "p3" changed from "" to "abc"
++ 1.2.1.3 K
+`
+	if changes.Stringify() != text {
+		t.Errorf("The text does not match. Expected\n%s\ngot\n%s", text, changes.Stringify())
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	changes := graph.Diff(root, root.Clone())
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,13 @@
+package errors
+
+type PersistenceError struct {
+	err string
+}
+
+func NewPersistenceError(err string) *PersistenceError {
+	return &PersistenceError{err: err}
+}
+
+func (e *PersistenceError) Error() string {
+	return e.err
+}
@@ -0,0 +1,24 @@
+package errors
+
+// Issue describes a problem found at the given JSON path of a document
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	err    string
+	issues []Issue
+}
+
+func NewValidationError(err string, issues []Issue) *ValidationError {
+	return &ValidationError{err: err, issues: issues}
+}
+
+func (e *ValidationError) Error() string {
+	return e.err
+}
+
+func (e *ValidationError) Issues() []Issue {
+	return e.issues
+}
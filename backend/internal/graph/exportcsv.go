@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+)
+
+// csvColumns are the fixed columns of the CSV format, which are followed by one column per property key
+var csvColumns = []string{"id", "parent_id", "name", "type", "color", "position", "ref"}
+
+// ToCsv returns this node and its descendants as a CSV document with one row per node in Depth-First Search order.
+// Each row holds the ID of the node, the ID of its parent, its name, type and color, its position among its siblings
+// starting at 0 and, for references, the ID of the referenced node. The properties of the nodes follow in one column
+// per key, sorted by key
+func (n *Node) ToCsv() ([]byte, error) {
+	keys := graphPropertyKeys(n.Traverse())
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write(append(append([]string{}, csvColumns...), keys...))
+	if err != nil {
+		return nil, err
+	}
+
+	var visit func(node *Node, parent string, position int) error
+	visit = func(node *Node, parent string, position int) error {
+		record := []string{node.Id, parent, node.Name, string(node.Type), node.Color, strconv.Itoa(position), node.Ref}
+		for _, key := range keys {
+			value := ""
+			if !node.IsReference() {
+				value = node.Properties[key]
+			}
+			record = append(record, value)
+		}
+		err := writer.Write(record)
+		if err != nil {
+			return err
+		}
+		for i, child := range node.Children {
+			err = visit(child, node.Id, i)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err = visit(n, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
@@ -0,0 +1,40 @@
+package graph_test
+
+import (
+	"testing"
+)
+
+func TestNode_ToCsv_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.Name = "B, \"quoted\""
+	b.SetProperty("name", "nomen")
+	bytes, err := root.ToCsv()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := `id,parent_id,name,type,color,position,ref,name,p1,p2
+0,,ens,lexeme,#dddddd,0,,,abc,xyz
+id_B,0,"B, ""quoted""",lexeme,#ff0000,0,,nomen,,
+id_C,0,C,lexeme,#ff0000,1,,,,
+id_D,0,D,opposition,#00ff00,2,,,,
+id_F,id_D,F,lexeme,#0000ff,0,,,,
+id_G,id_D,G,lexeme,#0000ff,1,,,,
+id_H,id_G,H,division,#00ffff,0,,,,
+id_I,id_G,I,lexeme,#00ffff,1,,,,
+id_E,0,E,lexeme,#00ff00,3,,,,
+`
+	actual := string(bytes)
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
@@ -0,0 +1,44 @@
+package graph
+
+import "encoding/json"
+
+// ToCytoscape returns the Cytoscape.js JSON representation of this node and its descendants. Every field of a node
+// becomes a data attribute, with its properties prefixed by "properties.", while a reference becomes an edge to the
+// referenced node, which is found in the graph of the given root (this node if nil) like ToGraphml
+func (n *Node) ToCytoscape(root *Node) ([]byte, error) {
+	nodes, edges := graphElements(n, root)
+	elements := map[string][]map[string]map[string]string{
+		"nodes": make([]map[string]map[string]string, 0, len(nodes)),
+		"edges": make([]map[string]map[string]string, 0, len(edges)),
+	}
+	for _, node := range nodes {
+		data := map[string]string{
+			"id":    node.Id,
+			"name":  node.Name,
+			"type":  string(node.Type),
+			"color": node.Color,
+		}
+		for key, value := range node.Properties {
+			data[propertyPrefix+key] = value
+		}
+		elements["nodes"] = append(elements["nodes"], map[string]map[string]string{"data": data})
+	}
+	for _, edge := range edges {
+		data := map[string]string{
+			"id":       edge.id,
+			"source":   edge.source,
+			"target":   edge.target,
+			"relation": edge.relation,
+		}
+		elements["edges"] = append(elements["edges"], map[string]map[string]string{"data": data})
+	}
+	document := map[string]any{
+		"data":     map[string]string{"name": n.Name},
+		"elements": elements,
+	}
+	bytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
@@ -0,0 +1,33 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	_ "embed"
+	"testing"
+)
+
+//go:embed test-graph.cyjs
+var testCytoscapeData []byte
+
+func TestNode_ToCytoscape_Success(t *testing.T) {
+	root, err := graph.NewLexeme("root", "root", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.Parse(testGraphData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	bytes, err := root.ToCytoscape(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := string(testCytoscapeData)
+	actual := string(bytes)
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// dotShapes maps each node type to its Graphviz shape
+var dotShapes = map[NodeType]string{
+	division:   "box",
+	lexeme:     "ellipse",
+	opposition: "diamond",
+}
+
+// ToDot returns the Graphviz DOT representation of this node and its descendants. Each node is filled with its
+// color, hidden nodes are drawn as unlabeled points and a reference becomes an edge to the referenced node. The given
+// root node of the graph resolves the nodes referenced from outside of this subtree, and defaults to this node
+func (n *Node) ToDot(root *Node) []byte {
+	if root == nil {
+		root = n
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("digraph %s {\n", dotQuote(n.Name)))
+	buffer.WriteString("  node [style=filled];\n")
+
+	declared := make(map[string]bool)
+	var edges []string
+	var visit func(node *Node)
+	visit = func(node *Node) {
+		declared[node.Id] = true
+		buffer.WriteString(fmt.Sprintf("  %s [%s];\n", dotQuote(node.Id), dotAttributes(node)))
+		for _, child := range node.Children {
+			if child.IsReference() {
+				edges = append(edges, fmt.Sprintf("  %s -> %s;\n", dotQuote(node.Id), dotQuote(child.Ref)))
+				continue
+			}
+			edges = append(edges, fmt.Sprintf("  %s -> %s;\n", dotQuote(node.Id), dotQuote(child.Id)))
+			visit(child)
+		}
+	}
+	visit(n)
+
+	// declare the referenced nodes outside of this subtree
+	nodes := nodesById(root)
+	for _, node := range n.Traverse() {
+		if target, found := nodes[node.Ref]; found && node.IsReference() && !declared[node.Ref] {
+			declared[node.Ref] = true
+			buffer.WriteString(fmt.Sprintf("  %s [%s];\n", dotQuote(target.Id), dotAttributes(target)))
+		}
+	}
+	for _, edge := range edges {
+		buffer.WriteString(edge)
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes()
+}
+
+// dotAttributes returns the attributes of a node
+func dotAttributes(node *Node) string {
+	color := node.Color
+	if color == "" {
+		color = DefaultColor
+	}
+	if isHidden(node) {
+		return fmt.Sprintf("shape=point, fillcolor=%s", dotQuote(color))
+	}
+	shape, found := dotShapes[node.Type]
+	if !found {
+		shape = dotShapes[lexeme]
+	}
+	return fmt.Sprintf("label=%s, shape=%s, fillcolor=%s", dotQuote(node.Name), shape, dotQuote(color))
+}
+
+// dotQuote returns the given string as a quoted DOT identifier
+func dotQuote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// isHidden returns true if the node's name starts with a space, which keeps it from being displayed
+func isHidden(node *Node) bool {
+	return strings.HasPrefix(node.Name, " ")
+}
@@ -0,0 +1,77 @@
+package graph_test
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+)
+
+//go:embed test-graph.dot
+var testDotData []byte
+
+func TestNode_ToDot_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := string(testDotData)
+	actual := string(root.ToDot(nil))
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToDot_HiddenAndReferences(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g.Name = " G \"hidden\""
+	_, err = root.LinkNode("id_B", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(root.ToDot(nil))
+	for _, expected := range []string{
+		"  \"id_G\" [shape=point, fillcolor=\"#0000ff\"];\n",
+		"  \"id_B\" -> \"id_H\";\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The DOT document does not contain %q:\n%s", expected, actual)
+		}
+	}
+	if strings.Count(actual, "\"id_H\" [") != 1 {
+		t.Errorf("The referenced node is declared more than once:\n%s", actual)
+	}
+}
+
+func TestNode_ToDot_ReferenceOutsideOfSubtree(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_B", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(b.ToDot(root))
+	expected := "  \"id_H\" [label=\"H\", shape=box, fillcolor=\"#00ffff\"];\n"
+	if !strings.Contains(actual, expected) {
+		t.Errorf("The DOT document does not contain %q:\n%s", expected, actual)
+	}
+}
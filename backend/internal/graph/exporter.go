@@ -0,0 +1,185 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultFormat is the format of the exporter used when no other one is requested
+const DefaultFormat = "json"
+
+// ExportOptions are the options of an export. Each exporter ignores the options it does not support
+type ExportOptions struct {
+	// Base is the base IRI of the SKOS resources
+	Base string
+	// MaxDepth omits the nodes deeper than the given depth, where the exported node has depth 0. 0 means no limit
+	MaxDepth int
+	// OmitHidden omits the hidden nodes
+	OmitHidden bool
+	// Root is the root node of the graph of the exported node, which resolves the nodes referenced from outside of the
+	// exported subtree. Nil means the exported node
+	Root *Node
+}
+
+// Exporter exports a node and its descendants in a given format
+type Exporter interface {
+	// Format returns the name of the format, e.g., "json"
+	Format() string
+	// ContentType returns the media type of the exported documents
+	ContentType() string
+	// Extension returns the file extension of the exported documents, without the dot
+	Extension() string
+	// Export returns the representation of the given node and its descendants
+	Export(node *Node, options ExportOptions) ([]byte, error)
+}
+
+// exporters are the registered exporters, in order of registration
+var exporters []Exporter
+
+// RegisterExporter registers an exporter, replacing the one registered with the same format. When several exporters
+// share a content type, the first one registered is negotiated
+func RegisterExporter(exporter Exporter) {
+	for i, e := range exporters {
+		if e.Format() == exporter.Format() {
+			exporters[i] = exporter
+			return
+		}
+	}
+	exporters = append(exporters, exporter)
+}
+
+// Exporters returns the registered exporters
+func Exporters() []Exporter {
+	return append([]Exporter{}, exporters...)
+}
+
+// FindExporter returns the exporter of the given format
+func FindExporter(format string) (Exporter, error) {
+	for _, e := range exporters {
+		if e.Format() == format {
+			return e, nil
+		}
+	}
+	return nil, errors.NewIllegalArgumentError(fmt.Sprintf("Unsupported export format %q", format))
+}
+
+// NegotiateExporter returns the exporter of the preferred media type of an Accept header which has one. Wildcards
+// and missing or unsatisfiable headers fall back to the default format
+func NegotiateExporter(accept string) Exporter {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		r := mediaRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), quality: 1}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if q, err := strconv.ParseFloat(value, 64); err == nil && strings.TrimSpace(name) == "q" {
+				r.quality = q
+			}
+		}
+		if r.mediaType != "" && r.quality > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	defaultExporter, _ := FindExporter(DefaultFormat)
+	for _, r := range ranges {
+		if r.mediaType == "*/*" {
+			return defaultExporter
+		}
+		for _, e := range exporters {
+			contentType := e.ContentType()
+			if contentType == r.mediaType ||
+				(strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(r.mediaType, "*"))) {
+				return e
+			}
+		}
+	}
+	return defaultExporter
+}
+
+// exporter is an exporter implemented by a function
+type exporter struct {
+	format      string
+	contentType string
+	extension   string
+	export      func(node *Node, options ExportOptions) ([]byte, error)
+}
+
+// NewExporter creates an exporter which calls the given function
+func NewExporter(format, contentType, extension string,
+	export func(node *Node, options ExportOptions) ([]byte, error)) Exporter {
+	return &exporter{format: format, contentType: contentType, extension: extension, export: export}
+}
+
+func (e *exporter) Format() string {
+	return e.format
+}
+
+func (e *exporter) ContentType() string {
+	return e.contentType
+}
+
+func (e *exporter) Extension() string {
+	return e.extension
+}
+
+func (e *exporter) Export(node *Node, options ExportOptions) ([]byte, error) {
+	return e.export(node, options)
+}
+
+// init registers the built-in exporters, JSON first so that it is the one negotiated for application/json
+func init() {
+	RegisterExporter(NewExporter("json", "application/json", "json", func(node *Node, _ ExportOptions) ([]byte, error) {
+		json, err := node.String()
+		return []byte(json), err
+	}))
+	RegisterExporter(NewExporter("text", "text/plain", "txt", func(node *Node, _ ExportOptions) ([]byte, error) {
+		return []byte(node.Stringify()), nil
+	}))
+	RegisterExporter(NewExporter("yaml", "text/yaml", "yaml", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToYaml(options.Root)
+	}))
+	RegisterExporter(NewExporter("csv", "text/csv", "csv", func(node *Node, _ ExportOptions) ([]byte, error) {
+		return node.ToCsv()
+	}))
+	RegisterExporter(NewExporter("dot", "text/vnd.graphviz", "dot", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToDot(options.Root), nil
+	}))
+	RegisterExporter(NewExporter("mermaid", "text/vnd.mermaid", "mmd", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToMermaid(options.Root, options.MaxDepth), nil
+	}))
+	RegisterExporter(NewExporter("svg", "image/svg+xml", "svg", func(node *Node, _ ExportOptions) ([]byte, error) {
+		return node.ToSvg(), nil
+	}))
+	RegisterExporter(NewExporter("graphml", "application/graphml+xml", "graphml", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToGraphml(options.Root), nil
+	}))
+	RegisterExporter(NewExporter("cytoscape", "application/vnd.cytoscape+json", "cyjs", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToCytoscape(options.Root)
+	}))
+	RegisterExporter(NewExporter("ttl", "text/turtle", "ttl", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToTurtle(options.Base)
+	}))
+	RegisterExporter(NewExporter("jsonld", "application/ld+json", "jsonld", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToJsonLd(options.Base)
+	}))
+	RegisterExporter(NewExporter("latex", "application/x-latex", "tex", func(node *Node, options ExportOptions) ([]byte, error) {
+		return node.ToLatex(LatexOptions{OmitHidden: options.OmitHidden, MaxDepth: options.MaxDepth}), nil
+	}))
+	RegisterExporter(NewExporter("markdown", "text/markdown", "md", func(node *Node, _ ExportOptions) ([]byte, error) {
+		return node.ToMarkdown(), nil
+	}))
+	RegisterExporter(NewExporter("opml", "text/x-opml", "opml", func(node *Node, _ ExportOptions) ([]byte, error) {
+		return node.ToOpml(), nil
+	}))
+}
@@ -0,0 +1,72 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"testing"
+)
+
+func TestFindExporter_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	exporter, err := graph.FindExporter("text")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if exporter.ContentType() != "text/plain" || exporter.Extension() != "txt" {
+		t.Errorf("Unexpected exporter %s %s", exporter.ContentType(), exporter.Extension())
+	}
+	bytes, err := exporter.Export(root, graph.ExportOptions{})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if string(bytes) != string(testPrintData) {
+		t.Errorf("Unexpected export %s", bytes)
+	}
+}
+
+func TestFindExporter_Failure(t *testing.T) {
+	_, err := graph.FindExporter("unknown")
+	if err == nil || err.Error() != "Unsupported export format \"unknown\"" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestNegotiateExporter(t *testing.T) {
+	for accept, expected := range map[string]string{
+		"":                                   "json",
+		"*/*":                                "json",
+		"application/json, text/plain, */*":  "json",
+		"text/plain":                         "text",
+		"text/csv; charset=utf-8":            "csv",
+		"text/yaml;q=0.5, text/vnd.graphviz": "dot",
+		"image/*":                            "svg",
+		"application/pdf":                    "json",
+		"text/turtle;q=0, text/markdown":     "markdown",
+	} {
+		actual := graph.NegotiateExporter(accept).Format()
+		if actual != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, accept, actual)
+		}
+	}
+}
+
+func TestRegisterExporter(t *testing.T) {
+	count := len(graph.Exporters())
+	graph.RegisterExporter(graph.NewExporter("names", "text/x-names", "names",
+		func(node *graph.Node, _ graph.ExportOptions) ([]byte, error) {
+			return []byte(node.Name), nil
+		}))
+	if len(graph.Exporters()) != count+1 {
+		t.Errorf("The exporter was not registered")
+		return
+	}
+	exporter := graph.NegotiateExporter("text/x-names")
+	if exporter.Format() != "names" {
+		t.Errorf("Unexpected exporter %q", exporter.Format())
+	}
+}
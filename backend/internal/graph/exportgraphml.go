@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+const (
+	childRelation     = "child"
+	referenceRelation = "reference"
+	edgePrefix        = "e_"
+	propertyPrefix    = "properties."
+)
+
+// graphEdge is an edge from a node to one of its children, or to the node referenced by one of its children. Its ID is
+// the ID of the child with a prefix, so that it does not clash with the IDs of the nodes
+type graphEdge struct {
+	id       string
+	source   string
+	target   string
+	relation string
+}
+
+// ToGraphml returns the GraphML representation of this node and its descendants. The name, type and color of each
+// node, as well as each of its properties, become data attributes, while a reference becomes an edge to the referenced
+// node, which is found in the graph of the given root (this node if nil) when it is not a descendant of this node
+func (n *Node) ToGraphml(root *Node) []byte {
+	nodes, edges := graphElements(n, root)
+	keys := graphPropertyKeys(nodes)
+
+	var buffer bytes.Buffer
+	buffer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	buffer.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	for _, field := range []string{"name", "type", "color"} {
+		buffer.WriteString(fmt.Sprintf("  <key id=\"%s\" for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", field, field))
+	}
+	for i, key := range keys {
+		buffer.WriteString(fmt.Sprintf("  <key id=\"p%d\" for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", i,
+			svgEscape(propertyPrefix+key)))
+	}
+	buffer.WriteString("  <key id=\"relation\" for=\"edge\" attr.name=\"relation\" attr.type=\"string\"/>\n")
+	buffer.WriteString(fmt.Sprintf("  <graph id=\"%s\" edgedefault=\"directed\">\n", svgEscape(n.Id)))
+	for _, node := range nodes {
+		buffer.WriteString(fmt.Sprintf("    <node id=\"%s\">\n", svgEscape(node.Id)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"name\">%s</data>\n", svgEscape(node.Name)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"type\">%s</data>\n", svgEscape(string(node.Type))))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"color\">%s</data>\n", svgEscape(node.Color)))
+		for i, key := range keys {
+			if value, found := node.Properties[key]; found {
+				buffer.WriteString(fmt.Sprintf("      <data key=\"p%d\">%s</data>\n", i, svgEscape(value)))
+			}
+		}
+		buffer.WriteString("    </node>\n")
+	}
+	for _, edge := range edges {
+		buffer.WriteString(fmt.Sprintf("    <edge id=\"%s\" source=\"%s\" target=\"%s\">\n", svgEscape(edge.id),
+			svgEscape(edge.source), svgEscape(edge.target)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"relation\">%s</data>\n", edge.relation))
+		buffer.WriteString("    </edge>\n")
+	}
+	buffer.WriteString("  </graph>\n</graphml>\n")
+	return buffer.Bytes()
+}
+
+// graphElements returns the nodes of the subtree of the given node which are not references in Depth-First Search
+// order, followed by the nodes of the graph of the given root which they reference outside of the subtree and by the
+// edges to their children and to the referenced nodes
+func graphElements(n, root *Node) ([]*Node, []graphEdge) {
+	if root == nil {
+		root = n
+	}
+	var nodes []*Node
+	var edges []graphEdge
+	var references []*Node
+	declared := make(map[string]bool)
+	for _, node := range n.Traverse() {
+		if node.IsReference() {
+			references = append(references, node)
+			continue
+		}
+		declared[node.Id] = true
+		nodes = append(nodes, node)
+		for _, child := range node.Children {
+			if child.IsReference() {
+				edges = append(edges, graphEdge{edgePrefix + child.Id, node.Id, child.Ref, referenceRelation})
+			} else {
+				edges = append(edges, graphEdge{edgePrefix + child.Id, node.Id, child.Id, childRelation})
+			}
+		}
+	}
+	// declare the referenced nodes outside of this subtree, without their own edges
+	all := nodesById(root)
+	for _, reference := range references {
+		if target, found := all[reference.Ref]; found && !declared[target.Id] {
+			declared[target.Id] = true
+			nodes = append(nodes, target)
+		}
+	}
+	// an edge to a missing node would make the document invalid
+	valid := make([]graphEdge, 0, len(edges))
+	for _, edge := range edges {
+		if declared[edge.target] {
+			valid = append(valid, edge)
+		}
+	}
+	return nodes, valid
+}
+
+// graphPropertyKeys returns the sorted union of the property keys of the given nodes
+func graphPropertyKeys(nodes []*Node) []string {
+	found := make(map[string]bool)
+	var keys []string
+	for _, node := range nodes {
+		for key := range node.Properties {
+			if !found[key] {
+				found[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,96 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	_ "embed"
+	"strings"
+	"testing"
+)
+
+//go:embed test-graph.graphml
+var testGraphmlData []byte
+
+func TestNode_ToGraphml_Success(t *testing.T) {
+	root, err := graph.NewLexeme("root", "root", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.Parse(testGraphData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := string(testGraphmlData)
+	actual := string(root.ToGraphml(nil))
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToGraphml_References(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_B", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.SetProperty("source", "<De Veritate>")
+	actual := string(root.ToGraphml(nil))
+	for _, expected := range []string{
+		"  <key id=\"p2\" for=\"node\" attr.name=\"properties.source\" attr.type=\"string\"/>\n",
+		"      <data key=\"p2\">&lt;De Veritate&gt;</data>\n",
+		"    <edge id=\"e_" + b.Children[0].Id + "\" source=\"id_B\" target=\"id_H\">\n      <data key=\"relation\">reference</data>\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The GraphML document does not contain %q:\n%s", expected, actual)
+		}
+	}
+	if strings.Count(actual, "<node id=") != 9 {
+		t.Errorf("The references should not be exported as nodes:\n%s", actual)
+	}
+}
+
+func TestNode_ToGraphml_ReferenceOutsideOfSubtree(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_B", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(b.ToGraphml(root))
+	for _, expected := range []string{
+		"    <node id=\"id_G\">\n      <data key=\"name\">G</data>\n",
+		"source=\"id_B\" target=\"id_G\">",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The GraphML document does not contain %q:\n%s", expected, actual)
+		}
+	}
+	// the children of the referenced node are not part of the subtree
+	if strings.Count(actual, "<node id=") != 2 || strings.Contains(actual, "id_H") {
+		t.Errorf("Unexpected nodes:\n%s", actual)
+	}
+	// without the root, the referenced node is missing, and so is the edge to it
+	if actual := string(b.ToGraphml(nil)); strings.Contains(actual, "id_G") {
+		t.Errorf("The edge to the missing node was exported:\n%s", actual)
+	}
+}
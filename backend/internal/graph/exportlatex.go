@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// latexOptions maps each node type to the forest options of its nodes
+var latexOptions = map[NodeType]string{
+	division:   "draw",
+	lexeme:     "draw, rounded corners",
+	opposition: "draw, double",
+}
+
+// latexEscapes maps the characters which are special to LaTeX, as well as the ligatures and the accented letters of
+// Latin, to their LaTeX commands
+var latexEscapes = func() map[rune]string {
+	escapes := map[rune]string{
+		'\\': `\textbackslash{}`,
+		'{':  `\{`,
+		'}':  `\}`,
+		'$':  `\$`,
+		'&':  `\&`,
+		'#':  `\#`,
+		'%':  `\%`,
+		'_':  `\_`,
+		'^':  `\textasciicircum{}`,
+		'~':  `\textasciitilde{}`,
+		'æ':  `\ae{}`,
+		'Æ':  `\AE{}`,
+		'œ':  `\oe{}`,
+		'Œ':  `\OE{}`,
+	}
+	accents := []struct {
+		command, lower, upper string
+	}{
+		{`=`, "āēīōūȳ", "ĀĒĪŌŪȲ"},
+		{`u`, "ăĕĭŏŭ", "ĂĔĬŎŬ"},
+		{`"`, "äëïöüÿ", "ÄËÏÖÜŸ"},
+		{`'`, "áéíóúý", "ÁÉÍÓÚÝ"},
+		{"`", "àèìòù", "ÀÈÌÒÙ"},
+		{`^`, "âêîôû", "ÂÊÎÔÛ"},
+	}
+	for _, accent := range accents {
+		for i, letter := range []rune(accent.lower) {
+			base := string("aeiouy"[i])
+			if base == "i" {
+				// the accents replace the dot of the i
+				base = `\i`
+			}
+			escapes[letter] = fmt.Sprintf(`\%s{%s}`, accent.command, base)
+		}
+		for i, letter := range []rune(accent.upper) {
+			escapes[letter] = fmt.Sprintf(`\%s{%c}`, accent.command, "AEIOUY"[i])
+		}
+	}
+	return escapes
+}()
+
+// LatexOptions are the options of the LaTeX export
+type LatexOptions struct {
+	// OmitHidden omits the hidden nodes, whose children are attached to their closest visible ancestor. The root is
+	// never omitted
+	OmitHidden bool
+	// MaxDepth omits the nodes deeper than the given depth, where the root has depth 0. 0 means no limit
+	MaxDepth int
+}
+
+// latexItem is a node whose brackets are open
+type latexItem struct {
+	depth    int
+	children bool
+}
+
+// ToLatex returns this node and its descendants as a forest environment of the forest LaTeX package, preceded by the
+// xcolor definitions of the colors of the nodes. Hidden nodes have no content and references are dashed
+func (n *Node) ToLatex(options LatexOptions) []byte {
+	var body strings.Builder
+	var colors []string
+	defined := make(map[string]bool)
+	var open []*latexItem
+	closeItem := func() {
+		item := open[len(open)-1]
+		open = open[:len(open)-1]
+		if item.children {
+			body.WriteString("\n" + strings.Repeat("  ", len(open)+1) + "]")
+		} else {
+			body.WriteString("]")
+		}
+	}
+
+	walkOutline(n, []int{1}, func(node *Node, counters []int) {
+		depth := len(counters) - 1
+		for len(open) > 0 && open[len(open)-1].depth >= depth {
+			closeItem()
+		}
+		if (options.MaxDepth > 0 && depth > options.MaxDepth) || (options.OmitHidden && depth > 0 && isHidden(node)) {
+			return
+		}
+		color := latexColor(node.Color)
+		if !defined[color] {
+			defined[color] = true
+			colors = append(colors, color)
+		}
+		if len(open) > 0 {
+			open[len(open)-1].children = true
+			body.WriteString("\n")
+		}
+		body.WriteString(fmt.Sprintf("%s[%s, fill=%s", strings.Repeat("  ", len(open)+1), latexContent(node),
+			latexColorName(color)))
+		if shape, found := latexOptions[node.Type]; found {
+			body.WriteString(", " + shape)
+		} else {
+			body.WriteString(", " + latexOptions[lexeme])
+		}
+		if node.IsReference() {
+			body.WriteString(", dashed")
+		}
+		open = append(open, &latexItem{depth: depth})
+	})
+	for len(open) > 0 {
+		closeItem()
+	}
+
+	var builder strings.Builder
+	for _, color := range colors {
+		builder.WriteString(fmt.Sprintf("\\definecolor{%s}{HTML}{%s}\n", latexColorName(color), color))
+	}
+	builder.WriteString("\\begin{forest}\n")
+	builder.WriteString(body.String())
+	builder.WriteString("\n\\end{forest}\n")
+	return []byte(builder.String())
+}
+
+// latexContent returns the escaped name of a node between braces, so that commas and brackets are not parsed by
+// forest. Hidden nodes have no content
+func latexContent(node *Node) string {
+	if isHidden(node) {
+		return "{}"
+	}
+	var builder strings.Builder
+	for _, r := range node.Name {
+		if escape, found := latexEscapes[r]; found {
+			builder.WriteString(escape)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return "{" + builder.String() + "}"
+}
+
+// latexColor returns the given color as six uppercase hex digits, as expected by the HTML model of xcolor
+func latexColor(color string) string {
+	hex := strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return latexColor(DefaultColor)
+	}
+	return hex
+}
+
+// latexColorName returns the xcolor name of the given hex color
+func latexColorName(color string) string {
+	return "color" + color
+}
@@ -0,0 +1,103 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"strings"
+	"testing"
+)
+
+func TestNode_ToLatex_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := `\definecolor{colorDDDDDD}{HTML}{DDDDDD}
+\definecolor{colorFF0000}{HTML}{FF0000}
+\definecolor{color00FF00}{HTML}{00FF00}
+\definecolor{color0000FF}{HTML}{0000FF}
+\definecolor{color00FFFF}{HTML}{00FFFF}
+\begin{forest}
+  [{ens}, fill=colorDDDDDD, draw, rounded corners
+    [{B}, fill=colorFF0000, draw, rounded corners]
+    [{C}, fill=colorFF0000, draw, rounded corners]
+    [{D}, fill=color00FF00, draw, double
+      [{F}, fill=color0000FF, draw, rounded corners]
+      [{G}, fill=color0000FF, draw, rounded corners
+        [{H}, fill=color00FFFF, draw]
+        [{I}, fill=color00FFFF, draw, rounded corners]
+      ]
+    ]
+    [{E}, fill=color00FF00, draw, rounded corners]
+  ]
+\end{forest}
+`
+	actual := string(root.ToLatex(graph.LatexOptions{}))
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToLatex_Escape(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.Name = "quæ_1 & cœlum, {ēns} ĭtem 100% #ÆŒ"
+	actual := string(root.ToLatex(graph.LatexOptions{}))
+	expected := `[{qu\ae{}\_1 \& c\oe{}lum, \{\={e}ns\} \u{\i}tem 100\% \#\AE{}\OE{}}, fill=colorFF0000`
+	if !strings.Contains(actual, expected) {
+		t.Errorf("The LaTeX document does not contain %q:\n%s", expected, actual)
+	}
+}
+
+func TestNode_ToLatex_Options(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g.Name = " G"
+	expected := `\begin{forest}
+  [{D}, fill=color00FF00, draw, double
+    [{F}, fill=color0000FF, draw, rounded corners]
+    [{H}, fill=color00FFFF, draw]
+    [{I}, fill=color00FFFF, draw, rounded corners]
+  ]
+\end{forest}
+`
+	d, err := root.FindNode("id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(d.ToLatex(graph.LatexOptions{OmitHidden: true}))
+	if !strings.HasSuffix(actual, expected) {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+	expected = `\begin{forest}
+  [{D}, fill=color00FF00, draw, double
+    [{F}, fill=color0000FF, draw, rounded corners]
+    [{}, fill=color0000FF, draw, rounded corners]
+  ]
+\end{forest}
+`
+	actual = string(d.ToLatex(graph.LatexOptions{MaxDepth: 1}))
+	if !strings.HasSuffix(actual, expected) {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+	if strings.Contains(actual, "color00FFFF") {
+		t.Errorf("The colors of the truncated nodes should not be defined:\n%s", actual)
+	}
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// mermaidShapes maps each node type to the opening and closing delimiters of its Mermaid shape
+var mermaidShapes = map[NodeType][2]string{
+	division:   {"[", "]"},
+	lexeme:     {"(", ")"},
+	opposition: {"{{", "}}"},
+}
+
+// ToMermaid returns this node and its descendants as a top-down Mermaid flowchart, truncated at the given depth unless
+// it is 0. Each node is styled with its color, divisions belong to the division class, hidden nodes are unlabeled
+// circles and a reference becomes a dotted edge to the referenced node. The given root node of the graph resolves the
+// nodes referenced from outside of the exported nodes, and defaults to this node
+func (n *Node) ToMermaid(root *Node, maxDepth int) []byte {
+	if root == nil {
+		root = n
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("flowchart TD\n")
+	ids := make(map[string]string)
+	mermaidId := func(id string) string {
+		if _, found := ids[id]; !found {
+			ids[id] = fmt.Sprintf("n%d", len(ids))
+		}
+		return ids[id]
+	}
+
+	declared := make(map[string]bool)
+	var edges, styles, divisions []string
+	declare := func(node *Node) string {
+		id := mermaidId(node.Id)
+		declared[node.Id] = true
+		buffer.WriteString(fmt.Sprintf("  %s%s\n", id, mermaidLabel(node)))
+		color := node.Color
+		if color == "" {
+			color = DefaultColor
+		}
+		styles = append(styles, fmt.Sprintf("  style %s fill:%s,stroke:#444444,color:%s\n", id, color, textColor(color)))
+		if node.Type == division {
+			divisions = append(divisions, id)
+		}
+		return id
+	}
+	var visit func(node *Node, depth int)
+	visit = func(node *Node, depth int) {
+		id := declare(node)
+		if maxDepth > 0 && depth >= maxDepth {
+			return
+		}
+		for _, child := range node.Children {
+			if child.IsReference() {
+				edges = append(edges, fmt.Sprintf("  %s -.-> %s\n", id, mermaidId(child.Ref)))
+				continue
+			}
+			edges = append(edges, fmt.Sprintf("  %s --> %s\n", id, mermaidId(child.Id)))
+			visit(child, depth+1)
+		}
+	}
+	visit(n, 0)
+
+	// declare the referenced nodes outside of the exported nodes
+	nodes := nodesById(root)
+	for _, node := range n.Traverse() {
+		_, found := ids[node.Ref]
+		if target, exists := nodes[node.Ref]; found && exists && node.IsReference() && !declared[node.Ref] {
+			declare(target)
+		}
+	}
+	for _, edge := range edges {
+		buffer.WriteString(edge)
+	}
+	buffer.WriteString("  classDef division stroke-width:3px,font-style:italic\n")
+	if len(divisions) > 0 {
+		buffer.WriteString(fmt.Sprintf("  class %s division\n", strings.Join(divisions, ",")))
+	}
+	for _, style := range styles {
+		buffer.WriteString(style)
+	}
+	return buffer.Bytes()
+}
+
+// mermaidLabel returns the shape and the quoted label of a node
+func mermaidLabel(node *Node) string {
+	if isHidden(node) {
+		return `((" "))`
+	}
+	shape, found := mermaidShapes[node.Type]
+	if !found {
+		shape = mermaidShapes[lexeme]
+	}
+	replacer := strings.NewReplacer("#", "#35;", `"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	return fmt.Sprintf(`%s"%s"%s`, shape[0], replacer.Replace(node.Name), shape[1])
+}
@@ -0,0 +1,95 @@
+package graph_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNode_ToMermaid_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := `flowchart TD
+  n0("ens")
+  n1("B")
+  n2("C")
+  n3{{"D"}}
+  n4("F")
+  n5("G")
+  n6["H"]
+  n7("I")
+  n8("E")
+  n0 --> n1
+  n0 --> n2
+  n0 --> n3
+  n3 --> n4
+  n3 --> n5
+  n5 --> n6
+  n5 --> n7
+  n0 --> n8
+  classDef division stroke-width:3px,font-style:italic
+  class n6 division
+  style n0 fill:#dddddd,stroke:#444444,color:#000000
+  style n1 fill:#ff0000,stroke:#444444,color:#ffffff
+  style n2 fill:#ff0000,stroke:#444444,color:#ffffff
+  style n3 fill:#00ff00,stroke:#444444,color:#000000
+  style n4 fill:#0000ff,stroke:#444444,color:#ffffff
+  style n5 fill:#0000ff,stroke:#444444,color:#ffffff
+  style n6 fill:#00ffff,stroke:#444444,color:#000000
+  style n7 fill:#00ffff,stroke:#444444,color:#000000
+  style n8 fill:#00ff00,stroke:#444444,color:#000000
+`
+	actual := string(root.ToMermaid(nil, 0))
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToMermaid_HiddenAndReferences(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	c, err := root.FindNode("id_C")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	c.Name = " C"
+	_, err = root.LinkNode("id_B", "id_I")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.Name = "B \"#1\""
+	actual := string(b.ToMermaid(root, 0))
+	expected := "flowchart TD\n  n0(\"B #quot;#35;1#quot;\")\n  n1(\"I\")\n  n0 -.-> n1\n"
+	if !strings.HasPrefix(actual, expected) {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+	if !strings.Contains(actual, "  style n1 fill:#00ffff,stroke:#444444,color:#000000\n") {
+		t.Errorf("The referenced node is not styled:\n%s", actual)
+	}
+	actual = string(root.ToMermaid(nil, 0))
+	if !strings.Contains(actual, "  n3((\" \"))\n") {
+		t.Errorf("The hidden node is not a circle:\n%s", actual)
+	}
+	// the division H is declared with its own shape and class
+	_, err = root.LinkNode("id_B", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual = string(b.ToMermaid(root, 0))
+	if !strings.Contains(actual, "  n2[\"H\"]\n") || !strings.Contains(actual, "  class n2 division\n") {
+		t.Errorf("The referenced division is not declared as a division:\n%s", actual)
+	}
+}
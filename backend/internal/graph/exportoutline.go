@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ToMarkdown returns this node and its descendants as a Markdown nested list indented by two spaces per level. The type
+// of a node which is not a lexeme is annotated as [type] and its color, unless it is the default one, as {color}, so
+// that ImportOutline can read the list back. References are listed as copies of the referenced nodes
+func (n *Node) ToMarkdown() []byte {
+	var buffer bytes.Buffer
+	walkOutline(n, []int{1}, func(node *Node, counters []int) {
+		buffer.WriteString(fmt.Sprintf("%s- %s%s\n", strings.Repeat("  ", len(counters)-1), node.Name,
+			outlineAnnotations(node)))
+	})
+	return buffer.Bytes()
+}
+
+// ToOpml returns this node and its descendants as an OPML 2.0 outline, where each outline element holds the name,
+// type and color of a node in its text, type and color attributes
+func (n *Node) ToOpml() []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	buffer.WriteString("<opml version=\"2.0\">\n")
+	buffer.WriteString(fmt.Sprintf("  <head>\n    <title>%s</title>\n  </head>\n", svgEscape(n.Name)))
+	buffer.WriteString("  <body>\n")
+	var visit func(node *Node, depth int)
+	visit = func(node *Node, depth int) {
+		indent := strings.Repeat("  ", depth+2)
+		nodeType := node.Type
+		if node.IsReference() {
+			nodeType = lexeme
+		}
+		buffer.WriteString(fmt.Sprintf("%s<outline text=\"%s\" type=\"%s\" color=\"%s\"", indent, svgEscape(node.Name),
+			svgEscape(string(nodeType)), svgEscape(node.Color)))
+		if len(node.Children) == 0 {
+			buffer.WriteString("/>\n")
+			return
+		}
+		buffer.WriteString(">\n")
+		for _, child := range node.Children {
+			visit(child, depth+1)
+		}
+		buffer.WriteString(indent + "</outline>\n")
+	}
+	visit(n, 0)
+	buffer.WriteString("  </body>\n</opml>\n")
+	return buffer.Bytes()
+}
+
+// outlineAnnotations returns the type and color annotations of a node
+func outlineAnnotations(node *Node) string {
+	annotations := ""
+	if node.Type != lexeme && node.Type != reference && node.Type != "" {
+		annotations += fmt.Sprintf(" [%s]", node.Type)
+	}
+	// ImportOutline only reads hex colors back
+	if colorPattern.MatchString(node.Color) && node.Color != DefaultColor {
+		annotations += fmt.Sprintf(" {%s}", node.Color)
+	}
+	return annotations
+}
@@ -0,0 +1,56 @@
+package graph_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNode_ToMarkdown_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := `- ens
+  - B {#ff0000}
+  - C {#ff0000}
+  - D [opposition] {#00ff00}
+    - F {#0000ff}
+    - G {#0000ff}
+      - H [division] {#00ffff}
+      - I {#00ffff}
+  - E {#00ff00}
+`
+	actual := string(root.ToMarkdown())
+	if expected != actual {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToOpml_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.Name = "B & <b>"
+	actual := string(root.ToOpml())
+	for _, expected := range []string{
+		"<opml version=\"2.0\">\n  <head>\n    <title>ens</title>\n  </head>\n  <body>\n" +
+			"    <outline text=\"ens\" type=\"lexeme\" color=\"#dddddd\">\n" +
+			"      <outline text=\"B &amp; &lt;b&gt;\" type=\"lexeme\" color=\"#ff0000\"/>\n",
+		"        <outline text=\"G\" type=\"lexeme\" color=\"#0000ff\">\n" +
+			"          <outline text=\"H\" type=\"division\" color=\"#00ffff\"/>\n" +
+			"          <outline text=\"I\" type=\"lexeme\" color=\"#00ffff\"/>\n        </outline>\n",
+		"    </outline>\n  </body>\n</opml>\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The OPML document does not contain %q:\n%s", expected, actual)
+		}
+	}
+}
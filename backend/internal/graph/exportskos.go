@@ -0,0 +1,307 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultBase is the default base IRI of the exported SKOS resources
+	DefaultBase  = "urn:divisio-entis:"
+	skosIri      = "http://www.w3.org/2004/02/skos/core#"
+	skosLanguage = "la"
+	skosScheme   = "scheme"
+)
+
+// skosResource is a SKOS concept or collection
+type skosResource struct {
+	iri        string
+	collection bool
+	label      string
+	notation   string
+	topConcept bool
+	broader    []string
+	narrower   []string
+	members    []string
+	properties map[string]string
+}
+
+// skosExport is the SKOS concept scheme of a graph
+type skosExport struct {
+	base      string
+	resources []*skosResource
+	byIri     map[string]*skosResource
+}
+
+// ToTurtle returns the SKOS concept scheme of this node and its descendants in Turtle. Lexemes and oppositions become
+// concepts labeled in Latin and divisions become collections whose members are the nodes they divide. Each concept is
+// narrower than its closest ancestor concept, a reference adds a parent to the referenced concept and properties become
+// annotations. The IRIs of the nodes are made of the given base, which must be an absolute IRI, followed by their IDs
+func (n *Node) ToTurtle(base string) ([]byte, error) {
+	s, err := newSkosExport(n, base)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("@prefix skos: <%s> .\n\n", skosIri))
+	buffer.WriteString(fmt.Sprintf("<%s> a skos:ConceptScheme", s.schemeIri()))
+	if n.Name != "" {
+		buffer.WriteString(fmt.Sprintf(" ;\n    skos:prefLabel %s", turtleLiteral(strings.TrimSpace(n.Name), skosLanguage)))
+	}
+	if top := s.topConcepts(); len(top) > 0 {
+		buffer.WriteString(fmt.Sprintf(" ;\n    skos:hasTopConcept %s", turtleIris(top)))
+	}
+	buffer.WriteString(" .\n")
+
+	for _, r := range s.resources {
+		buffer.WriteString("\n")
+		if r.collection {
+			buffer.WriteString(fmt.Sprintf("<%s> a skos:Collection", r.iri))
+		} else {
+			buffer.WriteString(fmt.Sprintf("<%s> a skos:Concept", r.iri))
+		}
+		statement := func(predicate, object string) {
+			buffer.WriteString(fmt.Sprintf(" ;\n    %s %s", predicate, object))
+		}
+		if r.label != "" {
+			statement("skos:prefLabel", turtleLiteral(r.label, skosLanguage))
+		}
+		statement("skos:notation", turtleLiteral(r.notation, ""))
+		if !r.collection {
+			statement("skos:inScheme", "<"+s.schemeIri()+">")
+		}
+		if r.topConcept {
+			statement("skos:topConceptOf", "<"+s.schemeIri()+">")
+		}
+		if len(r.broader) > 0 {
+			statement("skos:broader", turtleIris(r.broader))
+		}
+		if len(r.narrower) > 0 {
+			statement("skos:narrower", turtleIris(r.narrower))
+		}
+		if len(r.members) > 0 {
+			statement("skos:member", turtleIris(r.members))
+		}
+		for _, key := range sortedKeys(r.properties) {
+			statement("<"+s.propertyIri(key)+">", turtleLiteral(r.properties[key], ""))
+		}
+		buffer.WriteString(" .\n")
+	}
+	return buffer.Bytes(), nil
+}
+
+// ToJsonLd returns the SKOS concept scheme of this node and its descendants in JSON-LD (see ToTurtle)
+func (n *Node) ToJsonLd(base string) ([]byte, error) {
+	s, err := newSkosExport(n, base)
+	if err != nil {
+		return nil, err
+	}
+	ids := func(iris []string) []map[string]string {
+		objects := make([]map[string]string, len(iris))
+		for i, iri := range iris {
+			objects[i] = map[string]string{"@id": iri}
+		}
+		return objects
+	}
+	label := func(value string) map[string]string {
+		return map[string]string{"@value": value, "@language": skosLanguage}
+	}
+
+	scheme := map[string]any{"@id": s.schemeIri(), "@type": "skos:ConceptScheme"}
+	if n.Name != "" {
+		scheme["skos:prefLabel"] = label(strings.TrimSpace(n.Name))
+	}
+	if top := s.topConcepts(); len(top) > 0 {
+		scheme["skos:hasTopConcept"] = ids(top)
+	}
+	graph := []map[string]any{scheme}
+	for _, r := range s.resources {
+		object := map[string]any{"@id": r.iri, "@type": "skos:Concept", "skos:notation": r.notation}
+		if r.collection {
+			object["@type"] = "skos:Collection"
+		} else {
+			object["skos:inScheme"] = map[string]string{"@id": s.schemeIri()}
+		}
+		if r.label != "" {
+			object["skos:prefLabel"] = label(r.label)
+		}
+		if r.topConcept {
+			object["skos:topConceptOf"] = map[string]string{"@id": s.schemeIri()}
+		}
+		if len(r.broader) > 0 {
+			object["skos:broader"] = ids(r.broader)
+		}
+		if len(r.narrower) > 0 {
+			object["skos:narrower"] = ids(r.narrower)
+		}
+		if len(r.members) > 0 {
+			object["skos:member"] = ids(r.members)
+		}
+		for key, value := range r.properties {
+			object[s.propertyIri(key)] = value
+		}
+		graph = append(graph, object)
+	}
+	document := map[string]any{
+		"@context": map[string]string{"skos": skosIri},
+		"@graph":   graph,
+	}
+	bytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
+
+// newSkosExport collects the SKOS resources of the graph in Depth-First Search order, then walks it to relate them
+func newSkosExport(root *Node, base string) (*skosExport, error) {
+	if base == "" {
+		base = DefaultBase
+	}
+	err := checkBase(base)
+	if err != nil {
+		return nil, err
+	}
+	s := &skosExport{base: base, byIri: make(map[string]*skosResource)}
+	nodes := nodesById(root)
+	numbers := outlineNumbers(root)
+	for _, node := range root.Traverse() {
+		if !node.IsReference() {
+			s.resource(node, numbers)
+		}
+	}
+
+	var walk func(node *Node, concept string)
+	walk = func(node *Node, concept string) {
+		for _, child := range node.Children {
+			target, err := resolve(child, nodes)
+			if err != nil {
+				continue
+			}
+			iri := s.iri(target)
+			if node.Type == division {
+				r := s.byIri[s.iri(node)]
+				r.members = append(r.members, iri)
+			}
+			if child.IsReference() {
+				if target.Type != division && concept != "" {
+					s.relate(concept, iri)
+				}
+				continue
+			}
+			childConcept := concept
+			if child.Type != division {
+				if concept != "" {
+					s.relate(concept, iri)
+				}
+				childConcept = iri
+			}
+			walk(child, childConcept)
+		}
+	}
+	concept := ""
+	if root.Type != division {
+		concept = s.iri(root)
+	}
+	walk(root, concept)
+
+	for _, r := range s.resources {
+		r.topConcept = !r.collection && len(r.broader) == 0
+	}
+	return s, nil
+}
+
+// checkBase returns an error unless the given base is an absolute IRI without the characters Turtle forbids in IRIs,
+// which would let it end an IRI and inject statements
+func checkBase(base string) error {
+	iri, err := url.Parse(base)
+	if err != nil || !iri.IsAbs() || strings.ContainsFunc(base, func(r rune) bool {
+		return r <= ' ' || strings.ContainsRune("<>\"{}|^`\\", r)
+	}) {
+		return errors.NewIllegalArgumentError(fmt.Sprintf("invalid base IRI %q", base))
+	}
+	return nil
+}
+
+// resource adds the resource of a node
+func (s *skosExport) resource(node *Node, numbers map[string]string) {
+	r := &skosResource{
+		iri:        s.iri(node),
+		collection: node.Type == division,
+		label:      strings.TrimSpace(node.Name),
+		notation:   numbers[node.Id],
+		properties: node.Properties,
+	}
+	s.resources = append(s.resources, r)
+	s.byIri[r.iri] = r
+}
+
+// relate makes the given concept narrower than the given broader concept
+func (s *skosExport) relate(broader, narrower string) {
+	b, n := s.byIri[broader], s.byIri[narrower]
+	if b == nil || n == nil {
+		return
+	}
+	b.narrower = append(b.narrower, narrower)
+	n.broader = append(n.broader, broader)
+}
+
+// topConcepts returns the IRIs of the concepts without any broader concept
+func (s *skosExport) topConcepts() []string {
+	var iris []string
+	for _, r := range s.resources {
+		if r.topConcept {
+			iris = append(iris, r.iri)
+		}
+	}
+	return iris
+}
+
+// iri returns the IRI of a node
+func (s *skosExport) iri(node *Node) string {
+	return s.base + url.PathEscape(node.Id)
+}
+
+// schemeIri returns the IRI of the concept scheme
+func (s *skosExport) schemeIri() string {
+	return s.base + skosScheme
+}
+
+// propertyIri returns the IRI of the annotation predicate of a property
+func (s *skosExport) propertyIri(key string) string {
+	return s.base + "property/" + url.PathEscape(key)
+}
+
+// turtleLiteral returns a quoted Turtle string, with a language tag unless it is empty
+func turtleLiteral(value, language string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	literal := `"` + replacer.Replace(value) + `"`
+	if language != "" {
+		literal += "@" + language
+	}
+	return literal
+}
+
+// turtleIris returns a comma-separated list of IRIs
+func turtleIris(iris []string) string {
+	quoted := make([]string, len(iris))
+	for i, iri := range iris {
+		quoted[i] = "<" + iri + ">"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// sortedKeys returns the sorted keys of a map
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
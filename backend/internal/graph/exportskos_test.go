@@ -0,0 +1,148 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNode_ToTurtle_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	h, err := root.FindNode("id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	// move I under the division H, so that it is still narrower than G
+	_, err = root.MoveNode("id_G", "id_I", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g.Name = "G \"quoted\""
+	h.Name = " H"
+	bytes, err := root.ToTurtle("")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(bytes)
+	for _, expected := range []string{
+		"@prefix skos: <http://www.w3.org/2004/02/skos/core#> .\n",
+		"<urn:divisio-entis:scheme> a skos:ConceptScheme ;\n    skos:prefLabel \"ens\"@la ;\n    skos:hasTopConcept <urn:divisio-entis:0> .\n",
+		"<urn:divisio-entis:0> a skos:Concept ;\n    skos:prefLabel \"ens\"@la ;\n    skos:notation \"1\" ;\n" +
+			"    skos:inScheme <urn:divisio-entis:scheme> ;\n    skos:topConceptOf <urn:divisio-entis:scheme> ;\n" +
+			"    skos:narrower <urn:divisio-entis:id_B>, <urn:divisio-entis:id_C>, <urn:divisio-entis:id_D>, <urn:divisio-entis:id_E> ;\n" +
+			"    <urn:divisio-entis:property/p1> \"abc\" ;\n    <urn:divisio-entis:property/p2> \"xyz\" .\n",
+		"skos:prefLabel \"G \\\"quoted\\\"\"@la ;\n",
+		"<urn:divisio-entis:id_H> a skos:Collection ;\n    skos:prefLabel \"H\"@la ;\n    skos:notation \"1.3.2.1\" ;\n" +
+			"    skos:member <urn:divisio-entis:id_I> .\n",
+		"<urn:divisio-entis:id_I> a skos:Concept ;\n    skos:prefLabel \"I\"@la ;\n    skos:notation \"1.3.2.1.1\" ;\n" +
+			"    skos:inScheme <urn:divisio-entis:scheme> ;\n    skos:broader <urn:divisio-entis:id_G> .\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The Turtle document does not contain %q:\n%s", expected, actual)
+		}
+	}
+}
+
+func TestNode_ToTurtle_References(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_B", "id_I")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	bytes, err := root.ToTurtle("http://example.org/ens/")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	actual := string(bytes)
+	for _, expected := range []string{
+		"    skos:broader <http://example.org/ens/0> ;\n    skos:narrower <http://example.org/ens/id_I> .\n",
+		"    skos:broader <http://example.org/ens/id_B>, <http://example.org/ens/id_G> .\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The Turtle document does not contain %q:\n%s", expected, actual)
+		}
+	}
+	if strings.Count(actual, "a skos:Concept ;") != 8 {
+		t.Errorf("The references should not be exported as concepts:\n%s", actual)
+	}
+}
+
+func TestNode_ToTurtle_InvalidBase(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	for _, base := range []string{"ens/", "http://example.org/> <x> <y", "http://example.org/a b/", "http://example.org/\n"} {
+		_, err = root.ToTurtle(base)
+		if _, ok := err.(*errors.IllegalArgumentError); !ok {
+			t.Errorf("ToTurtle did not return an illegal argument error for the base %q: %v", base, err)
+		}
+		_, err = root.ToJsonLd(base)
+		if _, ok := err.(*errors.IllegalArgumentError); !ok {
+			t.Errorf("ToJsonLd did not return an illegal argument error for the base %q: %v", base, err)
+		}
+	}
+}
+
+func TestNode_ToJsonLd_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	bytes, err := root.ToJsonLd(graph.DefaultBase)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	var document struct {
+		Context map[string]string `json:"@context"`
+		Graph   []map[string]any  `json:"@graph"`
+	}
+	err = json.Unmarshal(bytes, &document)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if document.Context["skos"] != "http://www.w3.org/2004/02/skos/core#" {
+		t.Errorf("Unexpected context %v", document.Context)
+	}
+	if len(document.Graph) != 10 {
+		t.Errorf("Expected 10 resources, got %d", len(document.Graph))
+		return
+	}
+	root0 := document.Graph[1]
+	if root0["@id"] != "urn:divisio-entis:0" || root0["@type"] != "skos:Concept" ||
+		root0["urn:divisio-entis:property/p1"] != "abc" {
+		t.Errorf("Unexpected root concept %v", root0)
+	}
+	label, _ := root0["skos:prefLabel"].(map[string]any)
+	if label["@value"] != "ens" || label["@language"] != "la" {
+		t.Errorf("Unexpected label %v", root0["skos:prefLabel"])
+	}
+	h := document.Graph[7]
+	if h["@id"] != "urn:divisio-entis:id_H" || h["@type"] != "skos:Collection" {
+		t.Errorf("Unexpected collection %v", h)
+	}
+}
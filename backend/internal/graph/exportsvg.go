@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	svgCharWidth   = 7.0
+	svgFontSize    = 12
+	svgGap         = 16.0
+	svgHiddenSize  = 8.0
+	svgLevelHeight = 80.0
+	svgMargin      = 20.0
+	svgNodeHeight  = 28.0
+	svgPadding     = 12.0
+)
+
+// ToSvg renders this node and its descendants as a standalone SVG document laid out as a top-down tidy tree. Divisions
+// are drawn as rectangles, lexemes as rounded rectangles, oppositions as hexagons, references with a dashed outline and
+// hidden nodes as unlabeled dots. Each node is filled with its color
+func (n *Node) ToSvg() []byte {
+	nodes := tidyTree(n, svgWidth, svgGap)
+	width, height := 0.0, 0.0
+	for _, t := range nodes {
+		width = max(width, t.x+t.size/2)
+		height = max(height, svgY(t.depth)+svgNodeHeight/2)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		svgNumber(width+2*svgMargin), svgNumber(height+svgMargin), svgNumber(width+2*svgMargin),
+		svgNumber(height+svgMargin)))
+	buffer.WriteString(fmt.Sprintf(`<g font-family="sans-serif" font-size="%d" text-anchor="middle" transform="translate(%s,0)">`+"\n",
+		svgFontSize, svgNumber(svgMargin)))
+	for _, t := range nodes {
+		if t.parent == nil {
+			continue
+		}
+		top, bottom := svgY(t.parent.depth)+svgNodeHeight/2, svgY(t.depth)-svgNodeHeight/2
+		middle := (top + bottom) / 2
+		buffer.WriteString(fmt.Sprintf(`<path d="M%s,%s C%s,%s %s,%s %s,%s" fill="none" stroke="#888888"/>`+"\n",
+			svgNumber(t.parent.x), svgNumber(top), svgNumber(t.parent.x), svgNumber(middle),
+			svgNumber(t.x), svgNumber(middle), svgNumber(t.x), svgNumber(bottom)))
+	}
+	for _, t := range nodes {
+		svgNode(&buffer, t)
+	}
+	buffer.WriteString("</g>\n</svg>\n")
+	return buffer.Bytes()
+}
+
+// svgNode writes the shape and the label of a node
+func svgNode(buffer *bytes.Buffer, t *tidyNode) {
+	node := t.node
+	x, y := t.x, svgY(t.depth)
+	color := node.Color
+	if color == "" {
+		color = DefaultColor
+	}
+	if isHidden(node) {
+		buffer.WriteString(fmt.Sprintf(`<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="#888888"/>`+"\n",
+			svgNumber(x), svgNumber(y), svgNumber(svgHiddenSize/2), svgEscape(color)))
+		return
+	}
+	style := fmt.Sprintf(`fill="%s" stroke="#444444"`, svgEscape(color))
+	if node.IsReference() {
+		style += ` stroke-dasharray="4,2"`
+	}
+	left, top := x-t.size/2, y-svgNodeHeight/2
+	switch node.Type {
+	case division:
+		buffer.WriteString(fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n",
+			svgNumber(left), svgNumber(top), svgNumber(t.size), svgNumber(svgNodeHeight), style))
+	case opposition:
+		buffer.WriteString(fmt.Sprintf(`<polygon points="%s,%s %s,%s %s,%s %s,%s %s,%s %s,%s" %s/>`+"\n",
+			svgNumber(left), svgNumber(y),
+			svgNumber(left+svgPadding), svgNumber(top),
+			svgNumber(left+t.size-svgPadding), svgNumber(top),
+			svgNumber(left+t.size), svgNumber(y),
+			svgNumber(left+t.size-svgPadding), svgNumber(top+svgNodeHeight),
+			svgNumber(left+svgPadding), svgNumber(top+svgNodeHeight), style))
+	default:
+		buffer.WriteString(fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" %s/>`+"\n",
+			svgNumber(left), svgNumber(top), svgNumber(t.size), svgNumber(svgNodeHeight),
+			svgNumber(svgNodeHeight/2), style))
+	}
+	buffer.WriteString(fmt.Sprintf(`<text x="%s" y="%s" dy="0.35em" fill="%s">%s</text>`+"\n",
+		svgNumber(x), svgNumber(y), textColor(color), svgEscape(node.Name)))
+}
+
+// svgWidth returns the width of a node, estimated from the length of its label
+func svgWidth(node *Node) float64 {
+	if isHidden(node) {
+		return svgHiddenSize
+	}
+	return float64(utf8.RuneCountInString(node.Name))*svgCharWidth + 2*svgPadding
+}
+
+// svgY returns the vertical position of the center of the nodes at the given depth
+func svgY(depth int) float64 {
+	return svgMargin + svgNodeHeight/2 + float64(depth)*svgLevelHeight
+}
+
+// svgNumber formats a coordinate with at most two decimals
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// svgEscape escapes the XML special characters
+func svgEscape(s string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+	return replacer.Replace(s)
+}
+
+// textColor returns black or white, whichever is more readable on the given hex color
+func textColor(color string) string {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return "#000000"
+	}
+	r, g, b := float64(rgb>>16&0xff), float64(rgb>>8&0xff), float64(rgb&0xff)
+	if 0.299*r+0.587*g+0.114*b < 128 {
+		return "#ffffff"
+	}
+	return "#000000"
+}
@@ -0,0 +1,56 @@
+package graph_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNode_ToSvg_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g.Name = " G <hidden>"
+	_, err = root.LinkNode("id_B", "id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	svg := root.ToSvg()
+
+	// the document must be well-formed
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("The SVG document is not well-formed [%s]:\n%s", err, svg)
+			return
+		}
+	}
+	actual := string(svg)
+	for _, expected := range []string{
+		`<polygon points="94,114 106,100 113,100 125,114 113,128 106,128" fill="#00ff00" stroke="#444444"/>`,
+		`<text x="109.5" y="114" dy="0.35em" fill="#000000">D</text>`,
+		`fill="#00ffff" stroke="#444444" stroke-dasharray="4,2"/>`,
+		`<circle cx="127.25" cy="194" r="4" fill="#0000ff" stroke="#888888"/>`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("The SVG document does not contain %q:\n%s", expected, actual)
+		}
+	}
+	if strings.Contains(actual, "hidden") {
+		t.Errorf("The SVG document contains the label of a hidden node:\n%s", actual)
+	}
+}
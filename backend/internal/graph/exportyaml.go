@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+)
+
+const strTag = "!!str"
+
+// ToYaml returns the YAML representation of this node's children in the format of lexical-fields.yaml. A reference is
+// emitted as the referenced node, which is found in the graph of the given root (this node if nil), with its children.
+// Structurally identical subtrees, e.g., the shared ones, are emitted once with an anchor and referenced by aliases
+// everywhere else
+func (n *Node) ToYaml(root *Node) ([]byte, error) {
+	if root == nil {
+		root = n
+	}
+	nodes := nodesById(root)
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	for _, child := range yamlChildren(n.Children, nodes) {
+		document.Content = append(document.Content, yamlScalar(child.Name, strTag), yamlValue(child.Children, nodes))
+	}
+	anchorRepeatedSubtrees(document)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	err := encoder.Encode(document)
+	if err != nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to generate the YAML document [%s]", err))
+	}
+	err = encoder.Close()
+	if err != nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to generate the YAML document [%s]", err))
+	}
+	return buffer.Bytes(), nil
+}
+
+// yamlValue returns the YAML value of the given children: a mapping if they are all divisions, a list otherwise
+func yamlValue(children []*Node, nodes map[string]*Node) *yaml.Node {
+	children = yamlChildren(children, nodes)
+	if len(children) == 0 {
+		return yamlScalar("", nullTag)
+	}
+	divisions := true
+	for _, child := range children {
+		divisions = divisions && child.Type == division
+	}
+	if divisions {
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for _, child := range children {
+			mapping.Content = append(mapping.Content, yamlScalar(child.Name, strTag), yamlValue(child.Children, nodes))
+		}
+		return mapping
+	}
+	sequence := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, child := range children {
+		sequence.Content = append(sequence.Content, yamlItem(child, nodes))
+	}
+	return sequence
+}
+
+// yamlItem returns the list item of the given node. A ranked node is emitted as a numbered entry of a gradual field
+func yamlItem(node *Node, nodes map[string]*Node) *yaml.Node {
+	item := yamlScalar(node.Name, strTag)
+	if len(node.Children) > 0 {
+		item = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{item, yamlValue(node.Children, nodes)}}
+	}
+	rank := node.GetProperty(RankProperty)
+	if _, err := strconv.Atoi(rank); err != nil {
+		return item
+	}
+	if item.Kind == yaml.MappingNode {
+		item.Style = yaml.FlowStyle
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{yamlScalar(rank, intTag), item}}
+}
+
+// yamlChildren returns the given children, the references being replaced with the referenced nodes unless they are
+// missing
+func yamlChildren(children []*Node, nodes map[string]*Node) []*Node {
+	resolved := make([]*Node, len(children))
+	for i, child := range children {
+		resolved[i] = child
+		if target, err := resolve(child, nodes); err == nil {
+			resolved[i] = target
+		}
+	}
+	return resolved
+}
+
+// yamlScalar returns a scalar YAML node
+func yamlScalar(value, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// anchorRepeatedSubtrees visits the document in order and replaces each subtree identical to a previous one with an
+// alias to it, anchoring the first occurrence
+func anchorRepeatedSubtrees(document *yaml.Node) {
+	signatures := make(map[*yaml.Node]string)
+	yamlSignature(document, signatures)
+	anchors := &yamlAnchors{
+		signatures: signatures,
+		first:      make(map[string]*yaml.Node),
+		labels:     make(map[*yaml.Node]string),
+		names:      make(map[string]bool),
+	}
+	anchors.visit(document, "")
+}
+
+// yamlAnchors keeps track of the subtrees already emitted and of the anchor names already in use
+type yamlAnchors struct {
+	signatures map[*yaml.Node]string
+	first      map[string]*yaml.Node
+	labels     map[*yaml.Node]string
+	names      map[string]bool
+}
+
+// visit recursively replaces the repeated children of the given YAML node with aliases
+func (a *yamlAnchors) visit(node *yaml.Node, label string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			node.Content[i+1] = a.replace(node.Content[i+1], node.Content[i].Value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			node.Content[i] = a.replace(item, yamlLabel(item, label))
+		}
+	}
+}
+
+// replace returns an alias if an identical subtree was already visited, the visited subtree otherwise
+func (a *yamlAnchors) replace(node *yaml.Node, label string) *yaml.Node {
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return node
+	}
+	signature := a.signatures[node]
+	first, found := a.first[signature]
+	if !found {
+		a.first[signature] = node
+		a.labels[node] = label
+		a.visit(node, label)
+		return node
+	}
+	if first.Anchor == "" {
+		first.Anchor = a.name(a.labels[first])
+	}
+	return &yaml.Node{Kind: yaml.AliasNode, Value: first.Anchor, Alias: first}
+}
+
+// name returns a unique anchor name derived from the given label
+func (a *yamlAnchors) name(label string) string {
+	var builder strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && builder.Len() > 0 {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	base := builder.String()
+	if base == "" {
+		base = "anchor"
+	}
+	name := base
+	for i := 2; a.names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	a.names[name] = true
+	return name
+}
+
+// yamlLabel returns the name of a list item, if any, or the given default
+func yamlLabel(item *yaml.Node, label string) string {
+	for item.Kind == yaml.MappingNode && len(item.Content) > 0 {
+		if item.Content[0].Tag == intTag {
+			item = item.Content[1]
+			continue
+		}
+		return item.Content[0].Value
+	}
+	if item.Kind == yaml.ScalarNode {
+		return item.Value
+	}
+	return label
+}
+
+// yamlSignature recursively computes a hash identifying the structure of each YAML node
+func yamlSignature(node *yaml.Node, signatures map[*yaml.Node]string) string {
+	hash := sha256.New()
+	hash.Write([]byte(fmt.Sprintf("%d:%s:%q[", node.Kind, node.Tag, node.Value)))
+	for _, child := range node.Content {
+		hash.Write([]byte(yamlSignature(child, signatures)))
+	}
+	signature := hex.EncodeToString(hash.Sum(nil))
+	signatures[node] = signature
+	return signature
+}
@@ -0,0 +1,137 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"strings"
+	"testing"
+)
+
+func TestNode_ToYaml_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := `B:
+C:
+D:
+  - F
+  - G:
+      - H
+      - I
+E:
+`
+	actual, err := root.ToYaml(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if expected != string(actual) {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", expected, actual)
+	}
+}
+
+func TestNode_ToYaml_Anchors(t *testing.T) {
+	nodes, err := graph.ImportYaml(testFieldsData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err := graph.NewLexeme("0", "ens", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root.Children = nodes
+	actual, err := root.ToYaml(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	yaml := string(actual)
+	if strings.Count(yaml, "ens in alio") != 1 {
+		t.Errorf("The repeated subtree was not anchored:\n%s", yaml)
+	}
+	if !strings.Contains(yaml, "in se-in alio: &in_se_in_alio") || !strings.Contains(yaml, "ens simpliciter: *in_se_in_alio") {
+		t.Errorf("The anchor or the alias is missing:\n%s", yaml)
+	}
+	if !strings.Contains(yaml, "- 1: ens mobile") {
+		t.Errorf("The rank is missing:\n%s", yaml)
+	}
+}
+
+func TestNode_ToYaml_RoundTrip(t *testing.T) {
+	nodes, err := graph.ImportYaml(testFieldsData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err := graph.NewLexeme("0", "ens", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root.Children = nodes
+	exported, err := root.ToYaml(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	imported, err := graph.ImportYaml(exported)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	copied, err := graph.NewLexeme("0", "ens", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	copied.Children = imported
+	if root.Stringify() != copied.Stringify() {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", root.Stringify(), copied.Stringify())
+	}
+}
+
+func TestNode_ToYaml_RoundTripReferences(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_B", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	exported, err := root.ToYaml(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	imported, err := graph.ImportYaml(exported)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	// the shared subtree is imported wherever it is referenced
+	b := imported[0]
+	if b.Name != "B" || len(b.Children) != 1 || b.Children[0].Name != "G" || len(b.Children[0].Children) != 2 {
+		t.Errorf("The shared subtree was lost:\n%s", exported)
+		return
+	}
+	copied, err := graph.NewLexeme("0", "ens", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	copied.Children = imported
+	reexported, err := copied.ToYaml(nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if string(exported) != string(reexported) {
+		t.Errorf("strings do not match. Expected: %s\n. Actual: %s", exported, reexported)
+	}
+}
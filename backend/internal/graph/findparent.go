@@ -0,0 +1,17 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+)
+
+// FindParent returns the parent of the node with the given id
+func (n *Node) FindParent(id string) (*Node, error) {
+	if id == "" {
+		return nil, errors.NewIllegalArgumentError("id cannot be empty")
+	}
+	if parent, found := newIndex(n).parents[id]; found {
+		return parent, nil
+	}
+	return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent of the node with ID %q was not found", id))
+}
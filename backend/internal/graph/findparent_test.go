@@ -0,0 +1,30 @@
+package graph_test
+
+import (
+	"backend/internal/graph/errors"
+	"testing"
+)
+
+func TestNode_FindParent(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	parent, err := root.FindParent("id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if parent.Id != "id_G" {
+		t.Errorf("Unexpected parent %q", parent.Id)
+	}
+	_, err = root.FindParent("0")
+	if _, ok := err.(*errors.NodeNotFoundError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+	_, err = root.FindParent("")
+	if _, ok := err.(*errors.IllegalArgumentError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
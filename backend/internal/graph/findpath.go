@@ -0,0 +1,38 @@
+package graph
+
+// FindPath returns the path from this node to the node with the given ID, both included, with the outline number
+// Stringify assigns to each node
+func (n *Node) FindPath(id string) ([]PathStep, error) {
+	return n.findPath(newIndex(n), id)
+}
+
+// FindPath returns the path from the root node to the node with the given ID like Node.FindPath, found through the
+// index while holding the read lock
+func (g *Graph) FindPath(id string) ([]PathStep, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.root.findPath(g.index, id)
+}
+
+// findPath returns the path from this node to the node with the given ID in the graph whose index is given
+func (n *Node) findPath(x *index, id string) ([]PathStep, error) {
+	node, err := x.findNode(id)
+	if err != nil {
+		return nil, err
+	}
+	// climb up to this node, then number the nodes on the way down
+	nodes := []*Node{node}
+	for node != n {
+		node = x.parents[node.Id]
+		nodes = append(nodes, node)
+	}
+	path := make([]PathStep, 0, len(nodes))
+	counters := []int{1}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if i < len(nodes)-1 {
+			counters = append(counters, childIndex(nodes[i+1], nodes[i].Id)+1)
+		}
+		path = append(path, PathStep{Id: nodes[i].Id, Name: nodes[i].Name, Type: nodes[i].Type, Number: formatCounters(counters)})
+	}
+	return path, nil
+}
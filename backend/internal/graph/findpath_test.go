@@ -0,0 +1,55 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"reflect"
+	"testing"
+)
+
+func TestNode_FindPath(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	path, err := root.FindPath("id_H")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.PathStep{
+		{Id: "0", Name: "ens", Type: "lexeme", Number: "1"},
+		{Id: "id_D", Name: "D", Type: "opposition", Number: "1.3"},
+		{Id: "id_G", Name: "G", Type: "lexeme", Number: "1.3.2"},
+		{Id: "id_H", Name: "H", Type: "division", Number: "1.3.2.1"},
+	}
+	if !reflect.DeepEqual(path, expected) {
+		t.Errorf("Unexpected path %v", path)
+	}
+
+	path, err = root.FindPath("0")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(path) != 1 || path[0].Number != "1" {
+		t.Errorf("Unexpected path %v", path)
+	}
+}
+
+func TestNode_FindPath_Failure(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.FindPath("")
+	if _, ok := err.(*errors.IllegalArgumentError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+	_, err = root.FindPath("id_Z")
+	if _, ok := err.(*errors.NodeNotFoundError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
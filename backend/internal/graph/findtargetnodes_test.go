@@ -104,3 +104,27 @@ func TestNode_FindTargetNodes_FailsNodeIsEmpty(t *testing.T) {
 		t.Errorf("The error message does not match. Expected \"node cannot be nil\", got %s", err)
 	}
 }
+
+func TestNode_FindTargetNodes_ExcludesReferences(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	root, err = root.LinkNode("id_B", "id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	var expected []*graph.Node
+	expected = append(expected, &graph.Node{Id: "0", Name: "ens"})
+	expected = append(expected, &graph.Node{Id: "id_C", Name: "C"})
+	expected = append(expected, &graph.Node{Id: "id_E", Name: "E"})
+
+	actual, err := root.FindTargetNodes("id_B")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("Lists do not match")
+	}
+}
@@ -1,15 +1,41 @@
 package graph
 
 import (
+	"backend/internal/graph/errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
-// Graph contains the graph's root node
+const (
+	// DefaultBackups is the default number of backups kept by a graph
+	DefaultBackups = 10
+	// DefaultHistory is the default number of revisions kept by a graph
+	DefaultHistory = 100
+	// DefaultUndoLimit is the default number of operations which can be undone
+	DefaultUndoLimit = 100
+	backupsDir       = "backups"
+	timestampFmt     = "20060102T150405.000000000Z"
+)
+
+// Graph contains the graph's root node, which is only accessed through View and Update so that concurrent readers
+// and writers are synchronized, and the index of its nodes, which the operations keep up to date
 type Graph struct {
-	Root     *Node
-	Filename string
+	Filename  string
+	Backups   int
+	History   int
+	UndoLimit int
+	root      *Node
+	index     *index
+	revisions []Revision
+	undone    []Operation
+	done      []Operation
+	lock      sync.RWMutex
 }
 
 // NewGraph create a new graph
@@ -18,17 +44,132 @@ func NewGraph(name, filename string) (*Graph, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Graph{Root: root, Filename: filename}, nil
+	return &Graph{root: root, index: newIndex(root), Filename: filename, Backups: DefaultBackups, History: DefaultHistory,
+		UndoLimit: DefaultUndoLimit}, nil
+}
+
+// View calls fn with the root node while holding the read lock. fn must not modify the graph
+func (g *Graph) View(fn func(root *Node) error) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return fn(g.root)
+}
+
+// ViewNode calls fn with the root node and the node with the given ID, found through the index, while holding the read
+// lock. fn must not modify the graph
+func (g *Graph) ViewNode(id string, fn func(root, node *Node) error) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	node, err := g.index.findNode(id)
+	if err != nil {
+		return err
+	}
+	return fn(g.root, node)
+}
+
+// Update calls fn with the root node while holding the write lock. If fn succeeds, the root node it returns replaces
+// the current one, the graph is saved and a revision described by the given operation is recorded. Otherwise, as well
+// as if the graph cannot be saved, the graph is restored. The update can be undone by restoring the previous graph
+func (g *Graph) Update(operation string, fn func(root *Node) (*Node, error)) error {
+	return g.Do(NewReplaceOperation(operation, fn))
+}
+
+// Do applies the operation like Update and pushes it onto the undo stack, clearing the redo stack
+func (g *Graph) Do(operation Operation) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	err := g.apply(operation.String(), operation.apply, operation.revert)
+	if err != nil {
+		return err
+	}
+	g.done = append(g.done, operation)
+	if len(g.done) > g.UndoLimit {
+		g.done = g.done[len(g.done)-g.UndoLimit:]
+	}
+	g.undone = nil
+	return nil
+}
+
+// Undo reverts the latest operation and pushes it onto the redo stack
+func (g *Graph) Undo() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if len(g.done) == 0 {
+		return errors.NewIllegalArgumentError("there is nothing to undo")
+	}
+	operation := g.done[len(g.done)-1]
+	err := g.apply("undo: "+operation.String(), operation.revert, operation.apply)
+	if err != nil {
+		return err
+	}
+	g.done = g.done[:len(g.done)-1]
+	g.undone = append(g.undone, operation)
+	return nil
+}
+
+// Redo applies again the latest undone operation and pushes it back onto the undo stack
+func (g *Graph) Redo() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if len(g.undone) == 0 {
+		return errors.NewIllegalArgumentError("there is nothing to redo")
+	}
+	operation := g.undone[len(g.undone)-1]
+	err := g.apply("redo: "+operation.String(), operation.apply, operation.revert)
+	if err != nil {
+		return err
+	}
+	g.undone = g.undone[:len(g.undone)-1]
+	g.done = append(g.done, operation)
+	return nil
+}
+
+// apply applies fn to the graph, saves it and records a revision described by the given operation. fn keeps the index
+// up to date and fills in the defaults of the new nodes, so that readers never modify the graph, and leaves the graph
+// unchanged if it fails. If the graph cannot be saved, inverse restores it. The caller must hold the lock
+func (g *Graph) apply(operation string, fn, inverse func(root *Node, x *index) (*Node, error)) error {
+	root, err := fn(g.root, g.index)
+	if err != nil {
+		return err
+	}
+	g.root = root
+	err = g.save()
+	if err != nil {
+		root, inverseErr := inverse(g.root, g.index)
+		if inverseErr != nil {
+			// the file still holds the graph as it was before fn
+			log.Errorf("Failed to restore the graph [%s]", inverseErr)
+			g.load()
+			return err
+		}
+		g.root = root
+		return err
+	}
+	g.record(operation)
+	return nil
 }
 
 // Clear reset this graph
-func (g *Graph) Clear() *Graph {
-	g.Root.Children = make([]*Node, 0)
-	return g
+func (g *Graph) Clear() error {
+	return g.Update("clear the graph", func(root *Node) (*Node, error) {
+		root.Children = make([]*Node, 0)
+		return root, nil
+	})
 }
 
-// Load loads the graph as a JSON file from disk
+// Load loads the graph as a JSON file from disk together with its history, recording a new revision if the graph
+// differs from the latest one
 func (g *Graph) Load() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.load()
+	g.loadHistory()
+	g.done = nil
+	g.undone = nil
+}
+
+// load loads the graph as a JSON file from disk. The caller must hold the lock
+func (g *Graph) load() {
 	bytes, err := os.ReadFile(g.Filename)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to read the file [%s]", err)
@@ -37,27 +178,125 @@ func (g *Graph) Load() {
 	}
 
 	log.Debugf("Read %d bytes", len(bytes))
-	g.Root, err = g.Root.Parse(bytes)
+	root, err := new(Node).Parse(bytes)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to read the file [%s]", err)
 		log.Error(msg)
+		return
 	}
+	g.root = root
+	g.index = newIndex(root)
 }
 
 // Save saves the graph as a JSON file to disk
-func (g *Graph) Save() {
-	json, err := g.Root.String()
+func (g *Graph) Save() error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.save()
+}
+
+// save atomically saves the graph as a JSON file to disk, keeping a backup of the previous file. The caller must hold
+// the lock
+func (g *Graph) save() error {
+	json, err := g.root.String()
 	if err != nil {
 		msg := fmt.Sprintf("Failed to generate the JSON string [%s]", err)
 		log.Error(msg)
-		return
+		return err
 	}
 	bytes := []byte(json)
-	err = os.WriteFile(g.Filename, bytes, 0600)
+	err = g.backup()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to back up the file [%s]", err)
+		log.Error(msg)
+		return errors.NewPersistenceError(msg)
+	}
+	err = writeFile(g.Filename, bytes)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to save the file [%s]", err)
 		log.Error(msg)
-		return
+		return errors.NewPersistenceError(msg)
 	}
 	log.Debugf("Written %d bytes", len(bytes))
+	return nil
+}
+
+// backup copies the current file into the backups directory and removes the oldest backups
+func (g *Graph) backup() error {
+	if g.Backups <= 0 {
+		return nil
+	}
+	bytes, err := os.ReadFile(g.Filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(filepath.Dir(g.Filename), backupsDir)
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	base := filepath.Base(g.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+	name := prefix + time.Now().UTC().Format(timestampFmt) + ext
+	err = writeFile(filepath.Join(dir, name), bytes)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) && strings.HasSuffix(entry.Name(), ext) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	// timestamps sort chronologically
+	sort.Strings(backups)
+	for len(backups) > g.Backups {
+		err = os.Remove(filepath.Join(dir, backups[0]))
+		if err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// writeFile writes the given bytes to a temporary file which is synced to disk and then renamed to the given filename,
+// so that a crash never leaves a truncated file behind
+func writeFile(filename string, bytes []byte) error {
+	dir := filepath.Dir(filename)
+	file, err := os.CreateTemp(dir, "."+filepath.Base(filename)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(bytes)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Rename(file.Name(), filename)
+	if err != nil {
+		return err
+	}
+	// sync the directory so that the rename is durable
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
 }
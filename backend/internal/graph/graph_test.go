@@ -0,0 +1,184 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func provisionGraph(t *testing.T) *graph.Graph {
+	filename := filepath.Join(t.TempDir(), "graph.json")
+	err := os.WriteFile(filename, testGraphData, 0600)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	g, err := graph.NewGraph("ens", filename)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	g.Load()
+	return g
+}
+
+func TestGraph_Update_Success(t *testing.T) {
+	g := provisionGraph(t)
+	node, err := graph.NewLexeme("id_K", "K", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+		return root.AddNode("id_F", node)
+	})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	loaded, err := graph.NewGraph("ens", g.Filename)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	loaded.Load()
+	err = loaded.View(func(root *graph.Node) error {
+		_, err := root.FindNode("id_K")
+		return err
+	})
+	if err != nil {
+		t.Errorf("The updated graph was not saved [%s]", err)
+	}
+}
+
+func TestGraph_Update_Fails(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+		return root.RemoveNode("id_Z", "id_F")
+	})
+	if err == nil {
+		t.Errorf("Update did not return an error")
+		return
+	}
+	if err.Error() != "the parent node with ID \"id_Z\" was not found" {
+		t.Errorf("The error message does not match. Expected \"the parent node with ID \"id_Z\" was not found\", got %s", err)
+	}
+}
+
+func TestGraph_Clear(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_ = g.View(func(root *graph.Node) error {
+		if len(root.Children) != 0 {
+			t.Errorf("The graph was not cleared")
+		}
+		return nil
+	})
+}
+
+func TestGraph_ConcurrentUpdates(t *testing.T) {
+	g := provisionGraph(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			node, err := graph.NewLexeme(fmt.Sprintf("id_%d", i), fmt.Sprintf("%d", i), "")
+			if err != nil {
+				t.Errorf(err.Error())
+				return
+			}
+			err = g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+				return root.AddNode("id_D", node)
+			})
+			if err != nil {
+				t.Errorf(err.Error())
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = g.View(func(root *graph.Node) error {
+				root.Stringify()
+				_, err := root.FindTargetNodes("id_G")
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+
+	_ = g.View(func(root *graph.Node) error {
+		if len(root.Traverse()) != 59 {
+			t.Errorf("Expected 59 nodes, got %d", len(root.Traverse()))
+		}
+		return nil
+	})
+}
+
+func TestGraph_Save_Backups(t *testing.T) {
+	g := provisionGraph(t)
+	g.Backups = 3
+	for i := 0; i < 5; i++ {
+		err := g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+			root.Name = fmt.Sprintf("ens %d", i)
+			return root, nil
+		})
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(g.Filename), "backups"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(entries) != 3 {
+		t.Errorf("Expected 3 backups, got %d", len(entries))
+		return
+	}
+	bytes, err := os.ReadFile(filepath.Join(filepath.Dir(g.Filename), "backups", entries[2].Name()))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err := new(graph.Node).Parse(bytes)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if root.Name != "ens 3" {
+		t.Errorf("The latest backup does not match. Expected \"ens 3\", got %q", root.Name)
+	}
+}
+
+func TestGraph_Update_FailsSave(t *testing.T) {
+	g, err := graph.NewGraph("ens", filepath.Join(t.TempDir(), "missing", "graph.json"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	node, err := graph.NewLexeme("id_K", "K", "")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+		return root.AddNode("0", node)
+	})
+	if err == nil {
+		t.Errorf("Update did not return an error")
+		return
+	}
+	_ = g.View(func(root *graph.Node) error {
+		if len(root.Children) != 0 {
+			t.Errorf("The graph was not restored")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"encoding/json"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	historyDir  = "history"
+	snapshotFmt = "%06d.json"
+)
+
+// Revision describes a snapshot of the graph recorded after a successful mutation
+type Revision struct {
+	Revision  int       `json:"revision"`
+	Timestamp time.Time `json:"timestamp"`
+	Operation string    `json:"operation"`
+}
+
+// snapshot is a revision together with the graph's root node, as persisted in the history directory
+type snapshot struct {
+	Revision
+	Root *Node `json:"graph"`
+}
+
+// Revisions returns the recorded revisions, oldest first
+func (g *Graph) Revisions() []Revision {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	revisions := make([]Revision, len(g.revisions))
+	copy(revisions, g.revisions)
+	return revisions
+}
+
+// Revision returns the root node of the graph at the given revision
+func (g *Graph) Revision(revision int) (*Node, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.snapshot(revision)
+}
+
+// Restore replaces the graph with the one at the given revision, recording a new revision
+func (g *Graph) Restore(revision int) error {
+	return g.Update(fmt.Sprintf("restore revision %d", revision), func(root *Node) (*Node, error) {
+		return g.snapshot(revision)
+	})
+}
+
+// snapshot reads the root node of the graph at the given revision. The caller must hold the lock
+func (g *Graph) snapshot(revision int) (*Node, error) {
+	found := false
+	for _, r := range g.revisions {
+		found = found || r.Revision == revision
+	}
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the revision %d was not found", revision))
+	}
+	s, err := readSnapshot(filepath.Join(g.historyDir(), fmt.Sprintf(snapshotFmt, revision)))
+	if err != nil {
+		return nil, err
+	}
+	return s.Root, nil
+}
+
+// record persists the current graph as a new revision and removes the oldest revisions. The caller must hold the
+// lock. Failures are only logged, since the graph itself has already been saved
+func (g *Graph) record(operation string) {
+	if g.History <= 0 {
+		return
+	}
+	revision := 1
+	if len(g.revisions) > 0 {
+		revision = g.revisions[len(g.revisions)-1].Revision + 1
+	}
+	s := snapshot{
+		Revision: Revision{Revision: revision, Timestamp: time.Now().UTC(), Operation: operation},
+		Root:     g.root,
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		log.Errorf("Failed to generate the JSON string of revision %d [%s]", revision, err)
+		return
+	}
+	err = os.MkdirAll(g.historyDir(), 0700)
+	if err == nil {
+		err = writeFile(filepath.Join(g.historyDir(), fmt.Sprintf(snapshotFmt, revision)), bytes)
+	}
+	if err != nil {
+		log.Errorf("Failed to record revision %d [%s]", revision, err)
+		return
+	}
+	g.revisions = append(g.revisions, s.Revision)
+
+	for len(g.revisions) > g.History {
+		err = os.Remove(filepath.Join(g.historyDir(), fmt.Sprintf(snapshotFmt, g.revisions[0].Revision)))
+		if err != nil {
+			log.Errorf("Failed to remove revision %d [%s]", g.revisions[0].Revision, err)
+			return
+		}
+		g.revisions = g.revisions[1:]
+	}
+}
+
+// loadHistory reads the recorded revisions and records the current graph if it differs from the latest one. The
+// caller must hold the lock
+func (g *Graph) loadHistory() {
+	g.revisions = nil
+	entries, err := os.ReadDir(g.historyDir())
+	if err != nil && !os.IsNotExist(err) {
+		log.Errorf("Failed to read the history [%s]", err)
+	}
+	var latest *snapshot
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json")); err != nil {
+			continue
+		}
+		s, err := readSnapshot(filepath.Join(g.historyDir(), entry.Name()))
+		if err != nil {
+			log.Errorf("Failed to read the revision %s [%s]", entry.Name(), err)
+			continue
+		}
+		g.revisions = append(g.revisions, s.Revision)
+		if latest == nil || s.Revision.Revision > latest.Revision.Revision {
+			latest = s
+		}
+	}
+	sort.Slice(g.revisions, func(i, j int) bool {
+		return g.revisions[i].Revision < g.revisions[j].Revision
+	})
+
+	current, err := g.root.String()
+	if err != nil {
+		log.Errorf("Failed to generate the JSON string [%s]", err)
+		return
+	}
+	if latest != nil {
+		recorded, err := latest.Root.String()
+		if err == nil && recorded == current {
+			return
+		}
+	}
+	g.record("load the graph")
+}
+
+// historyDir returns the directory containing the revisions
+func (g *Graph) historyDir() string {
+	return filepath.Join(filepath.Dir(g.Filename), historyDir)
+}
+
+// readSnapshot reads a revision from disk
+func readSnapshot(filename string) (*snapshot, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.NewPersistenceError(fmt.Sprintf("failed to read the revision [%s]", err))
+	}
+	s := &snapshot{}
+	err = json.Unmarshal(bytes, s)
+	if err != nil || s.Root == nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to parse the revision [%v]", err))
+	}
+	s.Root.Traverse()
+	syncReferences(s.Root)
+	return s, nil
+}
@@ -0,0 +1,109 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"testing"
+)
+
+func TestGraph_Revisions_Success(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Update("delete F", func(root *graph.Node) (*graph.Node, error) {
+		return root.RemoveNode("id_D", "id_F")
+	})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	revisions := g.Revisions()
+	if len(revisions) != 3 {
+		t.Errorf("Expected 3 revisions, got %d", len(revisions))
+		return
+	}
+	expected := []string{"load the graph", "delete F", "clear the graph"}
+	for i, revision := range revisions {
+		if revision.Revision != i+1 || revision.Operation != expected[i] {
+			t.Errorf("Unexpected revision %v", revision)
+		}
+	}
+
+	root, err := g.Revision(2)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(root.Traverse()) != 8 {
+		t.Errorf("Expected 8 nodes, got %d", len(root.Traverse()))
+	}
+}
+
+func TestGraph_Restore_Success(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Restore(1)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_ = g.View(func(root *graph.Node) error {
+		if len(root.Traverse()) != 9 {
+			t.Errorf("Expected 9 nodes, got %d", len(root.Traverse()))
+		}
+		return nil
+	})
+	revisions := g.Revisions()
+	if revisions[len(revisions)-1].Operation != "restore revision 1" {
+		t.Errorf("Unexpected revision %v", revisions[len(revisions)-1])
+	}
+}
+
+func TestGraph_Revisions_Persisted(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	loaded, err := graph.NewGraph("ens", g.Filename)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	loaded.Load()
+	if len(loaded.Revisions()) != 2 {
+		t.Errorf("Expected 2 revisions, got %d", len(loaded.Revisions()))
+	}
+}
+
+func TestGraph_Revisions_Pruned(t *testing.T) {
+	g := provisionGraph(t)
+	g.History = 2
+	for i := 0; i < 3; i++ {
+		err := g.Clear()
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	}
+	revisions := g.Revisions()
+	if len(revisions) != 2 || revisions[0].Revision != 3 {
+		t.Errorf("Unexpected revisions %v", revisions)
+	}
+	_, err := g.Revision(1)
+	if err == nil {
+		t.Errorf("Revision did not return an error")
+		return
+	}
+	if err.Error() != "the revision 1 was not found" {
+		t.Errorf("The error message does not match. Expected \"the revision 1 was not found\", got %s", err)
+	}
+}
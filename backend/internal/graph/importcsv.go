@@ -0,0 +1,226 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// csvRow is a node read from a row of a CSV document
+type csvRow struct {
+	number   int
+	parent   string
+	position int
+	node     *Node
+}
+
+// csvImporter collects the rows of a CSV document and their issues
+type csvImporter struct {
+	rows   []*csvRow
+	byId   map[string]*csvRow
+	issues []csvIssue
+}
+
+// csvIssue is an issue of a row
+type csvIssue struct {
+	number  int
+	message string
+}
+
+// ImportCsv parses a CSV document in the format of ToCsv and returns the nodes without a parent, with their
+// descendants. Only the id, parent_id and name columns are required, and the columns which are not fixed hold
+// properties. Every issue, e.g., a duplicated ID, an orphan whose parent is missing or a cycle of parents or references, is reported with its row
+// number, the header being row 1
+func ImportCsv(data []byte) ([]*Node, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to parse the CSV document [%s]", err))
+	}
+	if len(records) == 0 {
+		return make([]*Node, 0), nil
+	}
+
+	i := &csvImporter{byId: make(map[string]*csvRow)}
+	columns := make(map[string]int)
+	properties := make(map[int]string)
+	for index, header := range records[0] {
+		if _, found := columns[header]; !found && isCsvColumn(header) {
+			columns[header] = index
+		} else {
+			properties[index] = header
+		}
+	}
+	for _, column := range csvColumns[:3] {
+		if _, found := columns[column]; !found {
+			i.issue(1, fmt.Sprintf("the column %q is missing", column))
+		}
+	}
+	if len(i.issues) > 0 {
+		return nil, i.error()
+	}
+
+	for index, record := range records[1:] {
+		cell := func(column string) string {
+			if j, found := columns[column]; found && j < len(record) {
+				return record[j]
+			}
+			return ""
+		}
+		row := i.row(index+2, cell)
+		for j, key := range properties {
+			if j < len(record) && record[j] != "" {
+				row.node.Properties[key] = record[j]
+			}
+		}
+	}
+	i.checkParents()
+	if len(i.issues) > 0 {
+		return nil, i.error()
+	}
+	nodes := i.tree()
+	i.checkReferences(nodes)
+	if len(i.issues) > 0 {
+		return nil, i.error()
+	}
+	return nodes, nil
+}
+
+// row reads a row and checks its cells
+func (i *csvImporter) row(number int, cell func(column string) string) *csvRow {
+	node := &Node{
+		Id:         cell("id"),
+		Name:       cell("name"),
+		Type:       NodeType(cell("type")),
+		Color:      cell("color"),
+		Ref:        cell("ref"),
+		Properties: make(map[string]string),
+		Children:   make([]*Node, 0),
+	}
+	row := &csvRow{number: number, parent: cell("parent_id"), position: math.MaxInt, node: node}
+	if node.Id == "" {
+		i.issue(number, "the ID cannot be empty")
+	} else if first, found := i.byId[node.Id]; found {
+		i.issue(number, fmt.Sprintf("duplicated ID %q, already used at row %d", node.Id, first.number))
+	} else {
+		i.byId[node.Id] = row
+		i.rows = append(i.rows, row)
+	}
+	switch node.Type {
+	case "":
+		node.Type = lexeme
+	case division, lexeme, opposition:
+	case reference:
+		if node.Ref == "" {
+			i.issue(number, "a reference must have a referenced node")
+		}
+	default:
+		i.issue(number, fmt.Sprintf("unknown type %q", node.Type))
+	}
+	if node.Color == "" {
+		node.Color = DefaultColor
+	} else if !colorPattern.MatchString(node.Color) {
+		i.issue(number, fmt.Sprintf("invalid color %q", node.Color))
+	}
+	if position := cell("position"); position != "" {
+		p, err := strconv.Atoi(position)
+		if err != nil || p < 0 {
+			i.issue(number, fmt.Sprintf("invalid position %q", position))
+		}
+		row.position = p
+	}
+	if !node.IsReference() && node.Name == "" {
+		i.issue(number, "the name cannot be empty")
+	}
+	return row
+}
+
+// checkParents reports the orphans, whose parent is missing, the children of references and the cycles
+func (i *csvImporter) checkParents() {
+	for _, row := range i.rows {
+		if row.parent == "" {
+			continue
+		}
+		parent, found := i.byId[row.parent]
+		if !found {
+			i.issue(row.number, fmt.Sprintf("the parent %q of the node %q was not found", row.parent, row.node.Id))
+		} else if parent.node.IsReference() {
+			i.issue(row.number, fmt.Sprintf("the parent %q of the node %q is a reference", row.parent, row.node.Id))
+		}
+	}
+	for _, row := range i.rows {
+		visited := make(map[string]bool)
+		for current := row; current != nil && current.parent != ""; current = i.byId[current.parent] {
+			if visited[current.node.Id] {
+				break
+			}
+			visited[current.node.Id] = true
+			if current.parent == row.node.Id {
+				i.issue(row.number, fmt.Sprintf("the node %q is its own ancestor", row.node.Id))
+				break
+			}
+		}
+	}
+}
+
+// checkReferences reports the references creating a cycle with the given nodes. The references to the nodes which are
+// not imported are checked once the nodes are added to a graph
+func (i *csvImporter) checkReferences(nodes []*Node) {
+	targets := make(map[string]*Node, len(i.byId))
+	for id, row := range i.byId {
+		targets[id] = row.node
+	}
+	// the nodes are the children of a single root, so that each cycle is reported once
+	for _, node := range cyclicReferences(&Node{Children: nodes}, targets) {
+		i.issue(i.byId[node.Id].number, fmt.Sprintf("the reference to %q creates a cycle", node.Ref))
+	}
+}
+
+// tree attaches each node to its parent, ordering siblings by position, and returns the nodes without a parent
+func (i *csvImporter) tree() []*Node {
+	sort.SliceStable(i.rows, func(a, b int) bool {
+		return i.rows[a].position < i.rows[b].position
+	})
+	nodes := make([]*Node, 0)
+	for _, row := range i.rows {
+		if row.parent == "" {
+			nodes = append(nodes, row.node)
+			continue
+		}
+		parent := i.byId[row.parent].node
+		parent.Children = append(parent.Children, row.node)
+	}
+	return nodes
+}
+
+// issue reports an issue of the given row
+func (i *csvImporter) issue(number int, message string) {
+	i.issues = append(i.issues, csvIssue{number, message})
+}
+
+// error returns the validation error listing the issues by row
+func (i *csvImporter) error() error {
+	sort.SliceStable(i.issues, func(a, b int) bool {
+		return i.issues[a].number < i.issues[b].number
+	})
+	issues := make([]errors.Issue, len(i.issues))
+	for j, issue := range i.issues {
+		issues[j] = errors.Issue{Path: fmt.Sprintf("row %d", issue.number), Message: issue.message}
+	}
+	return errors.NewValidationError(fmt.Sprintf("the CSV document has %d issue(s)", len(issues)), issues)
+}
+
+// isCsvColumn returns true if the given header is one of the fixed columns
+func isCsvColumn(header string) bool {
+	for _, column := range csvColumns {
+		if header == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"reflect"
+	"testing"
+)
+
+func TestImportCsv_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.SetProperty("name", "nomen")
+	_, err = root.LinkNode("id_C", "id_I")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	bytes, err := root.ToCsv()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	nodes, err := graph.ImportCsv(bytes)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(nodes) != 1 || !reflect.DeepEqual(root, nodes[0]) {
+		t.Errorf("The imported graph does not match: %v", nodes)
+	}
+}
+
+func TestImportCsv_Positions(t *testing.T) {
+	nodes, err := graph.ImportCsv([]byte(`name,id,parent_id,position,source
+posterius,b,a,1,
+ens,a,,,De Veritate
+prius,c,a,0,
+medium,d,a,,
+`))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := "1 ens\n1.1 prius\n1.2 posterius\n1.3 medium\n"
+	if len(nodes) != 1 || nodes[0].Stringify() != expected {
+		t.Errorf("Unexpected nodes %v", nodes)
+		return
+	}
+	if nodes[0].GetProperty("source") != "De Veritate" || nodes[0].Color != graph.DefaultColor {
+		t.Errorf("Unexpected node %v", nodes[0])
+	}
+}
+
+func TestImportCsv_Failure(t *testing.T) {
+	_, err := graph.ImportCsv([]byte(`id,parent_id,name,type,color,position
+a,,A,,,
+b,a,B,,red,
+a,b,A',,,
+c,x,C,,,
+d,e,D,,,
+e,d,E,unknown,,x
+f,f,F,,,
+g,a,,,,
+`))
+	validationError, ok := err.(*errors.ValidationError)
+	if !ok {
+		t.Errorf("Expected a validation error, got %v", err)
+		return
+	}
+	if err.Error() != "the CSV document has 9 issue(s)" {
+		t.Errorf("Unexpected error %q", err.Error())
+	}
+	expected := []errors.Issue{
+		{Path: "row 3", Message: "invalid color \"red\""},
+		{Path: "row 4", Message: "duplicated ID \"a\", already used at row 2"},
+		{Path: "row 5", Message: "the parent \"x\" of the node \"c\" was not found"},
+		{Path: "row 6", Message: "the node \"d\" is its own ancestor"},
+		{Path: "row 7", Message: "unknown type \"unknown\""},
+		{Path: "row 7", Message: "invalid position \"x\""},
+		{Path: "row 7", Message: "the node \"e\" is its own ancestor"},
+		{Path: "row 8", Message: "the node \"f\" is its own ancestor"},
+		{Path: "row 9", Message: "the name cannot be empty"},
+	}
+	if !reflect.DeepEqual(expected, validationError.Issues()) {
+		t.Errorf("Unexpected issues %v", validationError.Issues())
+	}
+}
+
+func TestImportCsv_ReferenceCycle(t *testing.T) {
+	_, err := graph.ImportCsv([]byte(`id,parent_id,name,type,ref
+a,,A,,
+b,a,B,,
+c,b,,reference,a
+d,,D,,
+e,d,,reference,b
+`))
+	validationError, ok := err.(*errors.ValidationError)
+	if !ok {
+		t.Errorf("Expected a validation error, got %v", err)
+		return
+	}
+	expected := []errors.Issue{{Path: "row 4", Message: "the reference to \"a\" creates a cycle"}}
+	if !reflect.DeepEqual(expected, validationError.Issues()) {
+		t.Errorf("Unexpected issues %v", validationError.Issues())
+	}
+}
+
+func TestImportCsv_MissingColumns(t *testing.T) {
+	_, err := graph.ImportCsv([]byte("id,name\na,A\n"))
+	if err == nil || err.Error() != "the CSV document has 1 issue(s)" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+)
+
+var (
+	// numberedPattern matches an entry of the Stringify format, e.g., "1.2.3 name"
+	numberedPattern = regexp.MustCompile(`^(\d+(?:\.\d+)*) (.*)$`)
+	// listPattern matches an item of a Markdown list, e.g., "  - name" or "  1. name"
+	listPattern = regexp.MustCompile(`^([ \t]*)(?:[-*+]|\d+[.)]) (.*)$`)
+	// outlineTypes are the node types which can be annotated
+	outlineTypes = map[string]NodeType{
+		string(division):   division,
+		string(lexeme):     lexeme,
+		string(opposition): opposition,
+	}
+)
+
+// opmlOutline is an outline element of an OPML document
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Type     string        `xml:"type,attr"`
+	Color    string        `xml:"color,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlDocument is an OPML document
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Body    struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// outlineEntry is an entry of an outline whose children may follow
+type outlineEntry struct {
+	level int
+	node  *Node
+}
+
+// ImportOutline parses an outline in the Stringify, Markdown nested-list or OPML format and returns its top-level
+// nodes. A name may end with a [type] annotation, e.g., [division], and a {color} annotation, e.g., {#ff0000}; other
+// nodes are lexemes with the default color. Names may be blank, like those of hidden nodes
+func ImportOutline(data []byte) ([]*Node, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return importOpml(data)
+	}
+	nodes := make([]*Node, 0)
+	var stack []outlineEntry
+	numbered := false
+	count := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		level, name := 0, ""
+		if match := numberedPattern.FindStringSubmatch(text); match != nil && (count == 0 || numbered) {
+			numbered = true
+			level, name = strings.Count(match[1], "."), match[2]
+		} else if match := listPattern.FindStringSubmatch(text); match != nil && !numbered {
+			level, name = len(strings.ReplaceAll(match[1], "\t", "    ")), match[2]
+		} else {
+			return nil, errors.NewIllegalArgumentError(fmt.Sprintf("the line %d is not an outline entry", line))
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].level >= level {
+			stack = stack[:len(stack)-1]
+		}
+		if numbered && level > len(stack) {
+			return nil, errors.NewIllegalArgumentError(fmt.Sprintf("the entry at line %d skips a level", line))
+		}
+		count++
+		node, err := outlineNode(name, "", "", count)
+		if err != nil {
+			return nil, errors.NewIllegalArgumentError(fmt.Sprintf("the entry at line %d is invalid [%s]", line, err))
+		}
+		if len(stack) == 0 {
+			nodes = append(nodes, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, outlineEntry{level, node})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewIllegalArgumentError(fmt.Sprintf("failed to read the outline [%s]", err))
+	}
+	return nodes, nil
+}
+
+// importOpml converts the outline elements of an OPML document into nodes
+func importOpml(data []byte) ([]*Node, error) {
+	var document opmlDocument
+	err := xml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, errors.NewIllegalArgumentError(fmt.Sprintf("failed to parse the OPML document [%s]", err))
+	}
+	count := 0
+	var convert func(outlines []opmlOutline) ([]*Node, error)
+	convert = func(outlines []opmlOutline) ([]*Node, error) {
+		nodes := make([]*Node, 0)
+		for _, outline := range outlines {
+			count++
+			node, err := outlineNode(outline.Text, outline.Type, outline.Color, count)
+			if err != nil {
+				return nil, errors.NewIllegalArgumentError(fmt.Sprintf("the outline %d is invalid [%s]", count, err))
+			}
+			node.Children, err = convert(outline.Outlines)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes, nil
+	}
+	return convert(document.Body.Outlines)
+}
+
+// AddOutline adds the top-level nodes of an imported outline as children of the node with the given ID. Since every
+// export starts with the exported node, a single top-level node named like this root node stands for it when the
+// outline is added to the root, so that its children are added instead of a second root
+func (n *Node) AddOutline(parent string, nodes []*Node) (*Node, error) {
+	if parent == n.Id && len(nodes) == 1 && nodes[0].Name == n.Name {
+		nodes = nodes[0].Children
+	}
+	x := newIndex(n)
+	for _, node := range nodes {
+		_, err := n.addNode(x, parent, node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// outlineNode creates a node with a fresh ID from an annotated name. The given type and color, if valid, override the
+// annotations
+func outlineNode(name, nodeType, color string, count int) (*Node, error) {
+	if count > maxImportedNodes {
+		return nil, fmt.Errorf("the outline has more than %d nodes", maxImportedNodes)
+	}
+	name, annotatedType, annotatedColor := outlineName(name)
+	if t, found := outlineTypes[nodeType]; found {
+		annotatedType = t
+	}
+	if colorPattern.MatchString(color) {
+		annotatedColor = color
+	}
+	if annotatedColor == "" {
+		annotatedColor = DefaultColor
+	}
+	// unlike newNode, the name may be empty
+	return &Node{
+		Id:         uuid.New().String(),
+		Name:       name,
+		Color:      annotatedColor,
+		Type:       annotatedType,
+		Properties: make(map[string]string),
+		Children:   make([]*Node, 0),
+	}, nil
+}
+
+// outlineName strips the type and color annotations from the end of a name. Brackets and braces which do not hold a
+// known type or a hex color are part of the name
+func outlineName(name string) (string, NodeType, string) {
+	nodeType, color := lexeme, ""
+	typed, colored := false, false
+	for {
+		trimmed := strings.TrimRight(name, " ")
+		start := strings.LastIndex(trimmed, " ")
+		if start < 0 {
+			break
+		}
+		annotation := trimmed[start+1:]
+		if t, found := outlineTypes[enclosed(annotation, "[", "]")]; found && !typed {
+			nodeType, typed = t, true
+		} else if c := enclosed(annotation, "{", "}"); colorPattern.MatchString(c) && !colored {
+			color, colored = c, true
+		} else {
+			break
+		}
+		name = trimmed[:start]
+	}
+	return name, nodeType, color
+}
+
+// enclosed returns the content of a string enclosed by the given delimiters, or an empty string
+func enclosed(s, start, end string) string {
+	if len(s) < len(start)+len(end) || !strings.HasPrefix(s, start) || !strings.HasSuffix(s, end) {
+		return ""
+	}
+	return s[len(start) : len(s)-len(end)]
+}
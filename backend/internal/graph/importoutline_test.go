@@ -0,0 +1,189 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestImportOutline_Formats(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	for format, data := range map[string][]byte{
+		"stringify": []byte(root.Stringify()),
+		"markdown":  root.ToMarkdown(),
+		"opml":      root.ToOpml(),
+	} {
+		nodes, err := graph.ImportOutline(data)
+		if err != nil {
+			t.Errorf("Failed to import the %s outline [%s]", format, err)
+			continue
+		}
+		if len(nodes) != 1 {
+			t.Errorf("Expected 1 %s node, got %d", format, len(nodes))
+			continue
+		}
+		if nodes[0].Stringify() != string(testPrintData) {
+			t.Errorf("Unexpected %s outline %s", format, nodes[0].Stringify())
+		}
+		h := nodes[0].Children[2].Children[1].Children[0]
+		if format != "stringify" && (h.Type != "division" || h.Color != "#00ffff") {
+			t.Errorf("The %s type and color were not imported: %v", format, h)
+		}
+	}
+}
+
+func TestImportOutline_Annotations(t *testing.T) {
+	nodes, err := graph.ImportOutline([]byte(`
+* ens [division]
+    * ens reale {#f00} [lexeme]
+        1. ens [sic] {red}
+        2.  hidden [opposition]
+* ens rationis
+`))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	type entry struct {
+		name, nodeType, color string
+	}
+	var actual []entry
+	for _, node := range nodes {
+		for _, n := range node.Traverse() {
+			actual = append(actual, entry{n.Name, string(n.Type), n.Color})
+		}
+	}
+	expected := []entry{
+		{"ens", "division", "#dddddd"},
+		{"ens reale", "lexeme", "#f00"},
+		{"ens [sic] {red}", "lexeme", "#dddddd"},
+		{" hidden", "opposition", "#dddddd"},
+		{"ens rationis", "lexeme", "#dddddd"},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Unexpected nodes %v", actual)
+	}
+}
+
+func TestImportOutline_Failure(t *testing.T) {
+	for data, expected := range map[string]string{
+		"1 ens\n1.1.1 B\n":             "the entry at line 2 skips a level",
+		"1 ens\n- B\n":                 "the line 2 is not an outline entry",
+		"<opml><body><outline></body>": "failed to parse the OPML document [XML syntax error on line 1: element <outline> closed by </body>]",
+	} {
+		_, err := graph.ImportOutline([]byte(data))
+		if _, ok := err.(*errors.IllegalArgumentError); !ok || err.Error() != expected {
+			t.Errorf("Expected error %q for %q, got %v", expected, data, err)
+		}
+	}
+}
+
+func TestImportOutline_BlankNames(t *testing.T) {
+	for format, data := range map[string]string{
+		"stringify": "1 ens\n1.1 \n1.2  \n1.3  [division]\n",
+		"markdown":  "- ens\n  - \n  -  \n  -  [division]\n",
+		"opml": `<opml><body><outline text="ens"><outline text=""/><outline text=" "/>` +
+			`<outline text="" type="division"/></outline></body></opml>`,
+	} {
+		nodes, err := graph.ImportOutline([]byte(data))
+		if err != nil {
+			t.Errorf("Failed to import the %s outline [%s]", format, err)
+			continue
+		}
+		children := nodes[0].Children
+		if len(children) != 3 || children[0].Name != "" || children[1].Name != " " || children[2].Name != "" ||
+			children[2].Type != "division" {
+			t.Errorf("Unexpected %s outline %q", format, nodes[0].Stringify())
+		}
+	}
+}
+
+func TestNode_AddOutline_Root(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	nodes, err := graph.ImportOutline(root.ToMarkdown())
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.AddOutline("0", nodes)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(root.Children) != 8 || root.Children[4].Name != "B" {
+		t.Errorf("The outline was not added under the root:\n%s", root.Stringify())
+	}
+	d, err := root.FindNode("id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	nodes, err = graph.ImportOutline(d.ToMarkdown())
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.AddOutline("id_D", nodes)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(d.Children) != 3 || d.Children[2].Name != "D" {
+		t.Errorf("The outline was not added as a child:\n%s", d.Stringify())
+	}
+}
+
+func TestImportOutline_RoundTrip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "volume", "graph.json"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	original, err := new(graph.Node).Parse(data)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	for format, data := range map[string][]byte{
+		"stringify": []byte(original.Stringify()),
+		"markdown":  original.ToMarkdown(),
+		"opml":      original.ToOpml(),
+	} {
+		nodes, err := graph.ImportOutline(data)
+		if err != nil {
+			t.Errorf("Failed to import the %s outline [%s]", format, err)
+			continue
+		}
+		root, err := new(graph.Node).Parse([]byte(`{"id": "0", "name": "ens"}`))
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		_, err = root.AddOutline("0", nodes)
+		if err != nil {
+			t.Errorf("Failed to add the %s outline [%s]", format, err)
+			continue
+		}
+		if root.Stringify() != original.Stringify() {
+			t.Errorf("Unexpected %s outline %s", format, root.Stringify())
+		}
+		// the root node is not imported, and the types of the other nodes are not printed
+		expected := original.Traverse()
+		for i, node := range root.Traverse()[1:] {
+			if format != "stringify" && node.Type != expected[i+1].Type {
+				t.Errorf("Unexpected %s type %q of %q", format, node.Type, node.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RankProperty is the property holding the rank of a numbered entry of a gradual field
+	RankProperty = "rank"
+	// maxImportedNodes limits the number of nodes materialized by expanding aliases
+	maxImportedNodes = 100000
+	intTag           = "!!int"
+	nullTag          = "!!null"
+)
+
+// ImportYaml parses a YAML document in the format of lexical-fields.yaml and returns one division node per section.
+// Mapping keys become division nodes and list items become lexeme nodes; aliases are materialized as new nodes
+func ImportYaml(bytes []byte) ([]*Node, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(bytes, &document)
+	if err != nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to parse the YAML document [%s]", err))
+	}
+	if len(document.Content) == 0 {
+		return make([]*Node, 0), nil
+	}
+	root := resolveAlias(document.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.NewParsingError("the YAML document must be a mapping of sections")
+	}
+	importer := &yamlImporter{}
+	return importer.sections(root)
+}
+
+// yamlImporter converts YAML nodes into graph nodes
+type yamlImporter struct {
+	count int
+}
+
+// sections converts each key of a mapping into a division node
+func (i *yamlImporter) sections(mapping *yaml.Node) ([]*Node, error) {
+	nodes := make([]*Node, 0)
+	for j := 0; j+1 < len(mapping.Content); j += 2 {
+		name := resolveAlias(mapping.Content[j]).Value
+		node, err := i.newNode(name, division)
+		if err != nil {
+			return nil, err
+		}
+		node.Children, err = i.children(mapping.Content[j+1])
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// children converts the value of a section or of a lexeme into the corresponding child nodes
+func (i *yamlImporter) children(value *yaml.Node) ([]*Node, error) {
+	value = resolveAlias(value)
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Tag == nullTag {
+			return make([]*Node, 0), nil
+		}
+		node, err := i.newNode(value.Value, lexeme)
+		if err != nil {
+			return nil, err
+		}
+		return []*Node{node}, nil
+	case yaml.SequenceNode:
+		return i.items(value)
+	case yaml.MappingNode:
+		return i.sections(value)
+	}
+	return nil, errors.NewParsingError(fmt.Sprintf("unexpected YAML value at line %d", value.Line))
+}
+
+// items converts the items of a list into lexeme nodes
+func (i *yamlImporter) items(sequence *yaml.Node) ([]*Node, error) {
+	nodes := make([]*Node, 0)
+	for _, item := range sequence.Content {
+		lexemes, err := i.item(item)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, lexemes...)
+	}
+	return nodes, nil
+}
+
+// item converts a list item into lexeme nodes. A numbered item of a gradual field keeps its rank as a property
+func (i *yamlImporter) item(item *yaml.Node) ([]*Node, error) {
+	item = resolveAlias(item)
+	switch item.Kind {
+	case yaml.ScalarNode:
+		if item.Tag == nullTag {
+			return make([]*Node, 0), nil
+		}
+		node, err := i.newNode(item.Value, lexeme)
+		if err != nil {
+			return nil, err
+		}
+		return []*Node{node}, nil
+	case yaml.SequenceNode:
+		return i.items(item)
+	case yaml.MappingNode:
+		nodes := make([]*Node, 0)
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key := resolveAlias(item.Content[j])
+			if key.Tag == intTag {
+				ranked, err := i.item(item.Content[j+1])
+				if err != nil {
+					return nil, err
+				}
+				for _, node := range ranked {
+					node.SetProperty(RankProperty, key.Value)
+				}
+				nodes = append(nodes, ranked...)
+				continue
+			}
+			node, err := i.newNode(key.Value, lexeme)
+			if err != nil {
+				return nil, err
+			}
+			node.Children, err = i.children(item.Content[j+1])
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes, nil
+	}
+	return nil, errors.NewParsingError(fmt.Sprintf("unexpected YAML item at line %d", item.Line))
+}
+
+// newNode creates a new node with a fresh ID
+func (i *yamlImporter) newNode(name string, nodeType NodeType) (*Node, error) {
+	i.count++
+	if i.count > maxImportedNodes {
+		return nil, errors.NewParsingError(fmt.Sprintf("the YAML document expands to more than %d nodes", maxImportedNodes))
+	}
+	node, err := newNode(uuid.New().String(), name, "", nodeType)
+	if err != nil {
+		return nil, errors.NewParsingError(fmt.Sprintf("failed to import the YAML document [%s]", err))
+	}
+	return node, nil
+}
+
+// resolveAlias returns the node an alias refers to
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
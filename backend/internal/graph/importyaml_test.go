@@ -0,0 +1,88 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	_ "embed"
+	"testing"
+)
+
+//go:embed test-fields.yaml
+var testFieldsData []byte
+
+func TestImportYaml_Success(t *testing.T) {
+	nodes, err := graph.ImportYaml(testFieldsData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(nodes) != 5 {
+		t.Errorf("Expected 5 sections, got %d", len(nodes))
+		return
+	}
+	for _, node := range nodes {
+		if node.Type != "division" {
+			t.Errorf("The section %q is not a division", node.Name)
+		}
+	}
+
+	simpliciter := nodes[1].Children[0]
+	secundumQuid := nodes[1].Children[1]
+	if simpliciter.Name != "ens simpliciter" || simpliciter.Type != "lexeme" || len(simpliciter.Children) != 2 {
+		t.Errorf("Unexpected node %v", simpliciter)
+		return
+	}
+	if simpliciter.Children[0].Name != "ens in se" || secundumQuid.Children[0].Name != "ens in se" {
+		t.Errorf("The alias was not expanded")
+	}
+	if simpliciter.Children[0].Id == secundumQuid.Children[0].Id {
+		t.Errorf("The expanded aliases share the ID %q", simpliciter.Children[0].Id)
+	}
+	if len(nodes[1].Children[2].Children) != 0 {
+		t.Errorf("The node %q has children", nodes[1].Children[2].Name)
+	}
+}
+
+func TestImportYaml_Ranks(t *testing.T) {
+	nodes, err := graph.ImportYaml(testFieldsData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	certitudo := nodes[2]
+	if certitudo.Children[0].Name != "ens mobile" || certitudo.Children[0].GetProperty(graph.RankProperty) != "1" {
+		t.Errorf("Unexpected node %v", certitudo.Children[0])
+	}
+	if certitudo.Children[1].Name != "ens quantum" || certitudo.Children[1].GetProperty(graph.RankProperty) != "2" {
+		t.Errorf("Unexpected node %v", certitudo.Children[1])
+	}
+	if len(certitudo.Children[1].Children) != 2 {
+		t.Errorf("The node %q has %d children", certitudo.Children[1].Name, len(certitudo.Children[1].Children))
+	}
+}
+
+func TestImportYaml_Mappings(t *testing.T) {
+	nodes, err := graph.ImportYaml(testFieldsData)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	completum := nodes[4]
+	if len(completum.Children) != 1 || completum.Children[0].Name != "alt1" || completum.Children[0].Type != "division" {
+		t.Errorf("Unexpected children %v", completum.Children)
+		return
+	}
+	if len(completum.Children[0].Children) != 2 {
+		t.Errorf("The node %q has %d children", completum.Children[0].Name, len(completum.Children[0].Children))
+	}
+}
+
+func TestImportYaml_FailsNotAMapping(t *testing.T) {
+	_, err := graph.ImportYaml([]byte("- a\n- b\n"))
+	if err == nil {
+		t.Errorf("ImportYaml did not return an error")
+		return
+	}
+	if err.Error() != "the YAML document must be a mapping of sections" {
+		t.Errorf("The error message does not match. Expected \"the YAML document must be a mapping of sections\", got %s", err)
+	}
+}
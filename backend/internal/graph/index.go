@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"slices"
+)
+
+// index maps the IDs of the nodes of a graph to the nodes, to their parents and to the references to them, so that
+// finding a node does not traverse the graph. A Graph holds the index of its root node, which it passes to the
+// operations so that they keep it up to date, while the methods of a standalone node index it on every call
+type index struct {
+	nodes      map[string]*Node
+	parents    map[string]*Node
+	references map[string]map[string]*Node
+}
+
+// newIndex indexes the given node and its descendants, filling in the defaults of the descendants like Traverse
+func newIndex(root *Node) *index {
+	x := &index{}
+	x.reindex(root)
+	return x
+}
+
+// reindex replaces the content of the index with the given node and its descendants, e.g., once the graph has been
+// modified in any way
+func (x *index) reindex(root *Node) {
+	x.nodes = make(map[string]*Node)
+	x.parents = make(map[string]*Node)
+	x.references = make(map[string]map[string]*Node)
+	x.add(nil, root)
+}
+
+// add indexes the given node and its descendants, the node being a child of the given parent unless it is nil, and
+// fills in their defaults
+func (x *index) add(parent, node *Node) {
+	if parent != nil {
+		fillDefaults(node)
+		x.parents[node.Id] = parent
+	}
+	x.nodes[node.Id] = node
+	if node.IsReference() {
+		if x.references[node.Ref] == nil {
+			x.references[node.Ref] = make(map[string]*Node)
+		}
+		x.references[node.Ref][node.Id] = node
+	}
+	for _, child := range node.Children {
+		x.add(node, child)
+	}
+}
+
+// remove removes the given node and its descendants from the index
+func (x *index) remove(node *Node) {
+	delete(x.nodes, node.Id)
+	delete(x.parents, node.Id)
+	if node.IsReference() {
+		delete(x.references[node.Ref], node.Id)
+	}
+	for _, child := range node.Children {
+		x.remove(child)
+	}
+}
+
+// findNode returns the node with the given ID
+func (x *index) findNode(id string) (*Node, error) {
+	if id == "" {
+		return nil, errors.NewIllegalArgumentError("id cannot be empty")
+	}
+	if node, found := x.nodes[id]; found {
+		return node, nil
+	}
+	return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the node with ID %q was not found", id))
+}
+
+// position returns the indexes of the given node and of its ancestors among their siblings, from the root down, which
+// sort the nodes in Depth-First Search order
+func (x *index) position(node *Node) []int {
+	var position []int
+	for parent := x.parents[node.Id]; parent != nil; node, parent = parent, x.parents[parent.Id] {
+		position = append(position, childIndex(parent, node.Id))
+	}
+	slices.Reverse(position)
+	return position
+}
+
+// syncReferences copies the name, color and properties of the referenced nodes into their references
+func (x *index) syncReferences() {
+	for _, node := range x.nodes {
+		x.syncReference(node)
+	}
+}
+
+// syncReferencesTo copies the name, color and properties of the given node into its references
+func (x *index) syncReferencesTo(node *Node) {
+	for _, reference := range x.references[node.Id] {
+		x.syncReference(reference)
+	}
+}
+
+// syncReference copies the name, color and properties of the referenced node into the given node if it is a reference
+func (x *index) syncReference(node *Node) {
+	if target, found := x.nodes[node.Ref]; found && node.IsReference() {
+		node.Name = target.Name
+		node.Color = target.Color
+		node.Properties = target.Properties
+	}
+}
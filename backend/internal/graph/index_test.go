@@ -0,0 +1,198 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// largeGraphSize is the number of nodes of the synthetic graph of the benchmarks
+const largeGraphSize = 100000
+
+// checkIndex checks that every node of the graph is found through the index together with the path to it, and that
+// the nodes of the other JSON representation which are not part of the graph are not found
+func checkIndex(t *testing.T, g *graph.Graph, other string) {
+	otherRoot, err := new(graph.Node).Parse([]byte(other))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	var nodes []*graph.Node
+	_ = g.View(func(root *graph.Node) error {
+		nodes = root.Traverse()
+		return nil
+	})
+	ids := make(map[string]bool)
+	for _, node := range nodes {
+		ids[node.Id] = true
+		err = g.ViewNode(node.Id, func(_, found *graph.Node) error {
+			if found != node {
+				return fmt.Errorf("the node %q is not indexed", node.Id)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		for _, child := range node.Children {
+			path, err := g.FindPath(child.Id)
+			if err != nil {
+				t.Errorf(err.Error())
+				return
+			}
+			if len(path) < 2 || path[len(path)-2].Id != node.Id {
+				t.Errorf("the parent of the node %q is not indexed", child.Id)
+				return
+			}
+		}
+	}
+	for _, node := range otherRoot.Traverse() {
+		if err := g.ViewNode(node.Id, func(_, _ *graph.Node) error { return nil }); err == nil && !ids[node.Id] {
+			t.Errorf("the node %q is still indexed", node.Id)
+		}
+	}
+}
+
+func TestGraph_Index(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	checkIndex(t, g, original)
+
+	err := g.Update("test", func(root *graph.Node) (*graph.Node, error) {
+		_, err := root.RemoveNode("0", "id_D")
+		if err != nil {
+			return nil, err
+		}
+		return root.RemoveNode("id_Z", "id_B")
+	})
+	if err == nil {
+		t.Errorf("Update did not return an error")
+		return
+	}
+	checkIndex(t, g, original)
+
+	err = g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkIndex(t, g, original)
+}
+
+// provisionLargeGraph returns a graph of largeGraphSize nodes, each node having 10 children, and the ID of its last
+// node
+func provisionLargeGraph(b *testing.B) (*graph.Graph, string) {
+	nodes := make([]*graph.Node, largeGraphSize)
+	for i := range nodes {
+		nodes[i] = &graph.Node{
+			Id:         fmt.Sprintf("id_%d", i),
+			Name:       fmt.Sprintf("node %d", i),
+			Type:       "lexeme",
+			Color:      graph.DefaultColor,
+			Properties: make(map[string]string),
+			Children:   make([]*graph.Node, 0),
+		}
+		if i > 0 {
+			parent := nodes[(i-1)/10]
+			parent.Children = append(parent.Children, nodes[i])
+		}
+	}
+	nodes[0].Id = "0"
+	json, err := nodes[0].String()
+	if err != nil {
+		b.Fatalf(err.Error())
+	}
+	filename := filepath.Join(b.TempDir(), "graph.json")
+	err = os.WriteFile(filename, []byte(json), 0600)
+	if err != nil {
+		b.Fatalf(err.Error())
+	}
+	g, err := graph.NewGraph("ens", filename)
+	if err != nil {
+		b.Fatalf(err.Error())
+	}
+	g.Backups = 0
+	g.History = 0
+	g.Load()
+	return g, nodes[len(nodes)-1].Id
+}
+
+func BenchmarkGraph_FindNode(b *testing.B) {
+	g, id := provisionLargeGraph(b)
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := g.ViewNode(id, func(_, _ *graph.Node) error {
+				return nil
+			})
+			if err != nil {
+				b.Fatalf(err.Error())
+			}
+		}
+	})
+	b.Run("traversed", func(b *testing.B) {
+		var root *graph.Node
+		_ = g.View(func(r *graph.Node) error {
+			root = r.Clone()
+			return nil
+		})
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := root.FindNode(id)
+			if err != nil {
+				b.Fatalf(err.Error())
+			}
+		}
+	})
+}
+
+func BenchmarkGraph_FindPath(b *testing.B) {
+	g, id := provisionLargeGraph(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := g.FindPath(id)
+		if err != nil {
+			b.Fatalf(err.Error())
+		}
+	}
+}
+
+func BenchmarkGraph_MoveNode(b *testing.B) {
+	g, id := provisionLargeGraph(b)
+	path, err := g.FindPath(id)
+	if err != nil {
+		b.Fatalf(err.Error())
+	}
+	parent := path[len(path)-2].Id
+	// the node moves back and forth across the runs of both benchmarks
+	moves := 0
+	move := func(b *testing.B, do func(from, to string) error) {
+		from, to := parent, "id_1"
+		if moves%2 == 1 {
+			from, to = to, from
+		}
+		moves++
+		err := do(from, to)
+		if err != nil {
+			b.Fatalf(err.Error())
+		}
+	}
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			move(b, func(from, to string) error {
+				return g.Do(graph.NewMoveOperation(from, id, to))
+			})
+		}
+	})
+	b.Run("traversed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			move(b, func(from, to string) error {
+				return g.Update("move", func(root *graph.Node) (*graph.Node, error) {
+					return root.MoveNode(from, id, to)
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"math"
+)
+
+// Orientation is the orientation of a layout
+type Orientation string
+
+const (
+	TopDown   = Orientation("top-down")
+	LeftRight = Orientation("left-right")
+	Radial    = Orientation("radial")
+)
+
+// Layout contains the computed position of every node of a graph. Coordinates are expressed in units, where adjacent
+// nodes are one unit apart and each level is one unit deeper than its parent's
+type Layout struct {
+	Orientation Orientation `json:"orientation"`
+	Extent      Extent      `json:"extent"`
+	Nodes       []Position  `json:"nodes"`
+}
+
+// Position is the computed position of a node together with the extent of its subtree
+type Position struct {
+	Id     string  `json:"id"`
+	Parent string  `json:"parent,omitempty"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Depth  int     `json:"depth"`
+	Extent Extent  `json:"extent"`
+}
+
+// Extent is a bounding box
+type Extent struct {
+	MinX float64 `json:"minX"`
+	MinY float64 `json:"minY"`
+	MaxX float64 `json:"maxX"`
+	MaxY float64 `json:"maxY"`
+}
+
+// Layout lays out this node and its descendants as a tidy tree with the given orientation. Top-down layouts grow
+// along y and left-right layouts along x, while radial layouts place the root at the origin and each level on a circle
+// whose radius is its depth. The nodes are listed in Depth-First Search order
+func (n *Node) Layout(orientation Orientation) (*Layout, error) {
+	nodes := tidyTree(n, func(*Node) float64 { return 0 }, 1)
+	breadth := 0.0
+	for _, t := range nodes {
+		breadth = max(breadth, t.x)
+	}
+
+	var place func(t *tidyNode) (float64, float64)
+	switch orientation {
+	case TopDown:
+		place = func(t *tidyNode) (float64, float64) { return t.x, float64(t.depth) }
+	case LeftRight:
+		place = func(t *tidyNode) (float64, float64) { return float64(t.depth), t.x }
+	case Radial:
+		place = func(t *tidyNode) (float64, float64) {
+			angle := 2 * math.Pi * t.x / (breadth + 1)
+			return round(float64(t.depth) * math.Cos(angle)), round(float64(t.depth) * math.Sin(angle))
+		}
+	default:
+		return nil, errors.NewIllegalArgumentError(fmt.Sprintf("unknown orientation %q", orientation))
+	}
+
+	layout := &Layout{Orientation: orientation, Nodes: make([]Position, len(nodes))}
+	indexes := make(map[*tidyNode]int, len(nodes))
+	for i, t := range nodes {
+		indexes[t] = i
+		x, y := place(t)
+		layout.Nodes[i] = Position{Id: t.node.Id, X: x, Y: y, Depth: t.depth, Extent: Extent{x, y, x, y}}
+		if t.parent != nil {
+			layout.Nodes[i].Parent = t.parent.node.Id
+		}
+	}
+	// the nodes are in Depth-First Search order, so the extent of each subtree is complete before its parent's
+	for i := len(nodes) - 1; i > 0; i-- {
+		parent := &layout.Nodes[indexes[nodes[i].parent]]
+		parent.Extent = parent.Extent.union(layout.Nodes[i].Extent)
+	}
+	layout.Extent = layout.Nodes[0].Extent
+	return layout, nil
+}
+
+// union returns the bounding box of both extents
+func (e Extent) union(other Extent) Extent {
+	return Extent{
+		MinX: min(e.MinX, other.MinX),
+		MinY: min(e.MinY, other.MinY),
+		MaxX: max(e.MaxX, other.MaxX),
+		MaxY: max(e.MaxY, other.MaxY),
+	}
+}
+
+// round rounds a coordinate to six decimals, so that radial layouts do not carry floating-point noise
+func round(f float64) float64 {
+	f = math.Round(f*1e6) / 1e6
+	if f == 0 {
+		// avoid negative zeros
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,92 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"testing"
+)
+
+func TestNode_Layout_TopDown(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	layout, err := root.Layout(graph.TopDown)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := map[string][2]float64{
+		"0": {1.5, 0}, "id_B": {0, 1}, "id_C": {1, 1}, "id_D": {2, 1}, "id_E": {3, 1},
+		"id_F": {1.5, 2}, "id_G": {2.5, 2}, "id_H": {2, 3}, "id_I": {3, 3},
+	}
+	if len(layout.Nodes) != len(expected) {
+		t.Errorf("Expected %d nodes, got %d", len(expected), len(layout.Nodes))
+		return
+	}
+	for _, position := range layout.Nodes {
+		if position.X != expected[position.Id][0] || position.Y != expected[position.Id][1] ||
+			position.Depth != int(expected[position.Id][1]) {
+			t.Errorf("Unexpected position %v", position)
+		}
+	}
+	d := layout.Nodes[3]
+	if d.Id != "id_D" || d.Parent != "0" || d.Extent != (graph.Extent{MinX: 1.5, MinY: 1, MaxX: 3, MaxY: 3}) {
+		t.Errorf("Unexpected extent %v", d)
+	}
+	if layout.Extent != (graph.Extent{MinX: 0, MinY: 0, MaxX: 3, MaxY: 3}) {
+		t.Errorf("Unexpected extent %v", layout.Extent)
+	}
+}
+
+func TestNode_Layout_LeftRight(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	layout, err := root.Layout(graph.LeftRight)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	h := layout.Nodes[6]
+	if h.Id != "id_H" || h.X != 3 || h.Y != 2 {
+		t.Errorf("Unexpected position %v", h)
+	}
+}
+
+func TestNode_Layout_Radial(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	layout, err := root.Layout(graph.Radial)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if layout.Nodes[0].X != 0 || layout.Nodes[0].Y != 0 {
+		t.Errorf("The root is not at the origin %v", layout.Nodes[0])
+	}
+	if layout.Nodes[1].Id != "id_B" || layout.Nodes[1].X != 1 || layout.Nodes[1].Y != 0 {
+		t.Errorf("Unexpected position %v", layout.Nodes[1])
+	}
+}
+
+func TestNode_Layout_FailsOrientation(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.Layout("diagonal")
+	if err == nil {
+		t.Errorf("Layout did not return an error")
+		return
+	}
+	if err.Error() != "unknown orientation \"diagonal\"" {
+		t.Errorf("The error message does not match. Expected \"unknown orientation \"diagonal\"\", got %s", err)
+	}
+}
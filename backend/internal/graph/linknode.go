@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// LinkNode adds a reference to the target node to the children of the given parent, so that the target is shared by
+// several parents
+func (n *Node) LinkNode(parent, target string) (*Node, error) {
+	_, err := n.linkNode(newIndex(n), parent, target, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// linkNode adds a reference with the given ID to the target node to the children of the given parent in the graph
+// whose index is given and returns the parent to which the reference was added
+func (n *Node) linkNode(x *index, parent, target, id string) (*Node, error) {
+	nodes := x.nodes
+	parentNode, found := nodes[parent]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", parent))
+	}
+	parentNode, err := resolve(parentNode, nodes)
+	if err != nil {
+		return nil, err
+	}
+	targetNode, found := nodes[target]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", target))
+	}
+	targetNode, err = resolve(targetNode, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range parentNode.Children {
+		if child.Id == targetNode.Id || (child.IsReference() && child.Ref == targetNode.Id) {
+			msg := fmt.Sprintf("the node %q is already a child of %q", targetNode.Id, parentNode.Id)
+			return nil, errors.NewIllegalArgumentError(msg)
+		}
+	}
+	// the parent cannot be reachable from the target
+	if reachable(targetNode, nodes)[parentNode.Id] {
+		msg := fmt.Sprintf("the node %q cannot be linked to %q without creating a cycle", targetNode.Id, parentNode.Id)
+		return nil, errors.NewIllegalArgumentError(msg)
+	}
+
+	node, err := NewReference(id, targetNode)
+	if err != nil {
+		return nil, err
+	}
+	parentNode.Children = append(parentNode.Children, node)
+	x.add(parentNode, node)
+	return parentNode, nil
+}
@@ -0,0 +1,116 @@
+package graph_test
+
+import (
+	"testing"
+)
+
+func TestNode_LinkNode_Success(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.LinkNode("id_B", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	found, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(found.Children) != 1 || !found.Children[0].IsReference() || found.Children[0].Ref != "id_G" {
+		t.Errorf("The node B has no reference to node G")
+		return
+	}
+	if found.Children[0].Name != "G" {
+		t.Errorf("The names do not match. Expected \"G\", got %q", found.Children[0].Name)
+	}
+}
+
+func TestNode_LinkNode_SharedUpdate(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.LinkNode("id_B", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	ref := *b.Children[0]
+	ref.Name = "GG"
+	ref.Color = red
+	ref.Children = nil
+	root, err = root.UpdateNode("id_B", &ref)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	g, err := root.FindNode("id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if g.Name != "GG" || g.Color != red || g.Type != "lexeme" {
+		t.Errorf("The referenced node was not updated: %v", g)
+	}
+	if b.Children[0].Name != "GG" || b.Children[0].Color != red {
+		t.Errorf("The reference was not updated: %v", b.Children[0])
+	}
+}
+
+func TestNode_LinkNode_FailsCycle(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_H", "id_D")
+	if err == nil {
+		t.Errorf("LinkNode did not return an error")
+		return
+	}
+	if err.Error() != "the node \"id_D\" cannot be linked to \"id_H\" without creating a cycle" {
+		t.Errorf("The error message does not match. Expected \"the node \"id_D\" cannot be linked to \"id_H\" without creating a cycle\", got %s", err)
+	}
+}
+
+func TestNode_LinkNode_FailsAlreadyChild(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_D", "id_F")
+	if err == nil {
+		t.Errorf("LinkNode did not return an error")
+		return
+	}
+	if err.Error() != "the node \"id_F\" is already a child of \"id_D\"" {
+		t.Errorf("The error message does not match. Expected \"the node \"id_F\" is already a child of \"id_D\"\", got %s", err)
+	}
+}
+
+func TestNode_LinkNode_FailsTargetNotFound(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.LinkNode("id_D", "id_Z")
+	if err == nil {
+		t.Errorf("LinkNode did not return an error")
+		return
+	}
+	if err.Error() != "the target node with ID \"id_Z\" was not found" {
+		t.Errorf("The error message does not match. Expected \"the target node with ID \"id_Z\" was not found\", got %s", err)
+	}
+}
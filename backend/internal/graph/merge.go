@@ -0,0 +1,223 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+)
+
+// Fields of a node reported by a conflict. DeletedField reports a node changed in the incoming graph but deleted from
+// the current one, whose base and incoming values are its parents
+const (
+	ParentField   = "parent"
+	NameField     = "name"
+	TypeField     = "type"
+	ColorField    = "color"
+	PropertyField = "property"
+	DeletedField  = "deleted"
+)
+
+// Conflict describes a field of a node whose incoming value could not be merged. The current value is kept
+type Conflict struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Field    string `json:"field"`
+	Property string `json:"property,omitempty"`
+	Base     string `json:"base,omitempty"`
+	Current  string `json:"current"`
+	Incoming string `json:"incoming"`
+}
+
+// Merge merges the incoming graph into this graph and returns the conflicts. Nodes with unknown IDs are grafted under
+// their parent and the changed fields of known nodes are updated; no node is ever removed. Without a base graph, a node
+// whose parent differs is a conflict, and so is a field the incoming graph lacks, since its value is kept. With a base
+// graph, which both graphs derive from, a change made on one side only
+// is applied, a change made on both sides is a conflict and a node removed from this graph stays removed
+func (n *Node) Merge(incoming, base *Node) (*Node, []Conflict, error) {
+	if incoming == nil {
+		return nil, nil, errors.NewIllegalArgumentError("incoming cannot be nil")
+	}
+	m := &merger{conflicts: make([]Conflict, 0)}
+	// the merger keeps its own index of this graph up to date
+	x := newIndex(n)
+	m.nodes, m.parents = x.nodes, x.parents
+	m.incomingParents = newIndex(incoming).parents
+	if base != nil {
+		x = newIndex(base)
+		m.baseNodes, m.baseParents = x.nodes, x.parents
+	}
+
+	for _, node := range incoming.Traverse() {
+		if node == incoming {
+			m.fields(n, node)
+			continue
+		}
+		current, found := m.nodes[node.Id]
+		if !found {
+			m.graft(node)
+			continue
+		}
+		m.move(current, node)
+		if !current.IsReference() && !node.IsReference() {
+			m.fields(current, node)
+		}
+	}
+
+	err := checkReferences(n, n, nodesById(n))
+	if err != nil {
+		return nil, nil, err
+	}
+	syncReferences(n)
+	return n, m.conflicts, nil
+}
+
+// merger keeps track of the nodes and parents of the graphs being merged
+type merger struct {
+	nodes           map[string]*Node
+	parents         map[string]*Node
+	incomingParents map[string]*Node
+	baseNodes       map[string]*Node
+	baseParents     map[string]*Node
+	conflicts       []Conflict
+}
+
+// graft adds a copy without children of an incoming node with an unknown ID under its parent
+func (m *merger) graft(node *Node) {
+	if baseNode, found := m.baseNodes[node.Id]; found {
+		// the node was removed from the current graph
+		if !sameFields(baseNode, node) || m.baseParents[node.Id].Id != m.incomingParents[node.Id].Id {
+			m.conflict(node, DeletedField, "", m.baseParents[node.Id].Id, "", m.incomingParents[node.Id].Id)
+		}
+		return
+	}
+	incomingParent := m.incomingParents[node.Id]
+	parent, found := m.nodes[incomingParent.Id]
+	if !found {
+		// the parent was removed from the current graph
+		m.conflict(node, ParentField, "", "", "", incomingParent.Id)
+		return
+	}
+	if parent.IsReference() {
+		parent = m.nodes[parent.Ref]
+	}
+	if parent == nil {
+		m.conflict(node, ParentField, "", "", "", incomingParent.Id)
+		return
+	}
+	grafted := *node
+	grafted.Children = make([]*Node, 0)
+	grafted.Properties = copyProperties(node.Properties)
+	parent.Children = append(parent.Children, &grafted)
+	m.nodes[grafted.Id] = &grafted
+	m.parents[grafted.Id] = parent
+}
+
+// move moves a known node to its incoming parent if only the incoming graph moved it, and reports a conflict if the
+// parents differ otherwise
+func (m *merger) move(current, node *Node) {
+	parent := m.parents[current.Id]
+	incomingParent := m.incomingParents[node.Id]
+	if parent == nil || parent.Id == incomingParent.Id {
+		// the root node never moves
+		return
+	}
+	baseParent, found := m.baseParents[node.Id]
+	if !found || (baseParent.Id != parent.Id && baseParent.Id != incomingParent.Id) {
+		base := ""
+		if found {
+			base = baseParent.Id
+		}
+		m.conflict(current, ParentField, "", base, parent.Id, incomingParent.Id)
+		return
+	}
+	if baseParent.Id == incomingParent.Id {
+		// only the current graph moved the node
+		return
+	}
+	newParent, found := m.nodes[incomingParent.Id]
+	if found && newParent.IsReference() {
+		newParent, found = m.nodes[newParent.Ref]
+	}
+	if !found || newParent == nil || reachable(current, m.nodes)[newParent.Id] {
+		m.conflict(current, ParentField, "", baseParent.Id, parent.Id, incomingParent.Id)
+		return
+	}
+	detachChild(parent, current.Id)
+	newParent.Children = append(newParent.Children, current)
+	m.parents[current.Id] = newParent
+}
+
+// fields merges the name, type, color and properties of an incoming node into the current one
+func (m *merger) fields(current, node *Node) {
+	baseNode, found := m.baseNodes[node.Id]
+	merge := func(field, property string, get func(n *Node) string, set func(value string)) {
+		currentValue, incomingValue := get(current), get(node)
+		if currentValue == incomingValue {
+			return
+		}
+		baseValue := ""
+		if found {
+			baseValue = get(baseNode)
+		}
+		switch {
+		case !found && incomingValue == "":
+			// without a base, the field cannot be told removed from missing, so that the current value is kept
+			m.conflict(current, field, property, "", currentValue, incomingValue)
+		case !found:
+			set(incomingValue)
+		case incomingValue == baseValue:
+			// only the current graph changed the field
+		case currentValue == baseValue:
+			set(incomingValue)
+		default:
+			m.conflict(current, field, property, baseValue, currentValue, incomingValue)
+		}
+	}
+
+	merge(NameField, "", func(n *Node) string { return n.Name }, func(v string) { current.Name = v })
+	merge(TypeField, "", func(n *Node) string { return string(n.Type) }, func(v string) { current.Type = NodeType(v) })
+	merge(ColorField, "", func(n *Node) string { return n.Color }, func(v string) { current.Color = v })
+	for _, key := range propertyKeys(current, node) {
+		key := key
+		merge(PropertyField, key, func(n *Node) string { return n.Properties[key] }, func(v string) {
+			if v == "" {
+				delete(current.Properties, key)
+			} else {
+				current.SetProperty(key, v)
+			}
+		})
+	}
+}
+
+// conflict records a conflict
+func (m *merger) conflict(node *Node, field, property, base, current, incoming string) {
+	m.conflicts = append(m.conflicts, Conflict{
+		Id:       node.Id,
+		Name:     node.Name,
+		Field:    field,
+		Property: property,
+		Base:     base,
+		Current:  current,
+		Incoming: incoming,
+	})
+}
+
+// sameFields returns true if both nodes have the same name, type, color and properties
+func sameFields(a, b *Node) bool {
+	if a.Name != b.Name || a.Type != b.Type || a.Color != b.Color {
+		return false
+	}
+	for _, key := range propertyKeys(a, b) {
+		if a.Properties[key] != b.Properties[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyProperties returns a copy of the given properties
+func copyProperties(properties map[string]string) map[string]string {
+	copied := make(map[string]string, len(properties))
+	for key, value := range properties {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,185 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"reflect"
+	"testing"
+)
+
+func TestNode_Merge_WithoutBase(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	incoming := root.Clone()
+	k, err := graph.NewLexeme("id_K", "K", red)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = incoming.AddNode("id_G", k)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = incoming.MoveNode("id_D", "id_F", "id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	c, _ := incoming.FindNode("id_C")
+	c.Name = "C2"
+
+	root, conflicts, err := root.Merge(incoming, nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.Conflict{{Id: "id_F", Name: "F", Field: graph.ParentField, Current: "id_D", Incoming: "id_B"}}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("The conflicts do not match. Expected %v, got %v", expected, conflicts)
+		return
+	}
+	if _, err := root.FindNode("id_K"); err != nil {
+		t.Errorf("The new node was not grafted [%s]", err)
+	}
+	c, _ = root.FindNode("id_C")
+	if c.Name != "C2" {
+		t.Errorf("The node was not renamed")
+	}
+	d, _ := root.FindNode("id_D")
+	if len(d.Children) != 2 || d.Children[0].Id != "id_F" {
+		t.Errorf("The conflicting node was moved")
+	}
+}
+
+func TestNode_Merge_WithBase(t *testing.T) {
+	base, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	current := base.Clone()
+	b, _ := current.FindNode("id_B")
+	b.Name = "B1"
+	e, _ := current.FindNode("id_E")
+	e.Color = red
+	_, err = current.RemoveNode("0", "id_C")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	incoming := base.Clone()
+	b, _ = incoming.FindNode("id_B")
+	b.Name = "B2"
+	e, _ = incoming.FindNode("id_E")
+	e.SetProperty("source", "De Veritate")
+	_, err = incoming.MoveNode("id_D", "id_F", "id_E")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	merged, conflicts, err := current.Merge(incoming, base)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.Conflict{{Id: "id_B", Name: "B1", Field: graph.NameField, Base: "B", Current: "B1", Incoming: "B2"}}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("The conflicts do not match. Expected %v, got %v", expected, conflicts)
+		return
+	}
+	if _, err := merged.FindNode("id_C"); err == nil {
+		t.Errorf("The removed node was restored")
+	}
+	e, _ = merged.FindNode("id_E")
+	if e.Color != red || e.GetProperty("source") != "De Veritate" {
+		t.Errorf("The changes of both sides were not merged")
+	}
+	if len(e.Children) != 1 || e.Children[0].Id != "id_F" {
+		t.Errorf("The node was not moved")
+	}
+}
+
+func TestNode_Merge_WithoutBase_KeepsMissingFields(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	incoming := root.Clone()
+	b, _ := root.FindNode("id_B")
+	b.SetProperty("source", "De Veritate")
+	incomingC, _ := incoming.FindNode("id_C")
+	incomingC.Color = ""
+	incomingC.SetProperty("source", "De Ente")
+
+	root, conflicts, err := root.Merge(incoming, nil)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.Conflict{
+		{Id: "id_B", Name: "B", Field: graph.PropertyField, Property: "source", Current: "De Veritate"},
+		{Id: "id_C", Name: "C", Field: graph.ColorField, Current: red},
+	}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("The conflicts do not match. Expected %v, got %v", expected, conflicts)
+		return
+	}
+	b, _ = root.FindNode("id_B")
+	c, _ := root.FindNode("id_C")
+	if b.GetProperty("source") != "De Veritate" || c.Color != red || c.GetProperty("source") != "De Ente" {
+		t.Errorf("The current values were not kept")
+	}
+}
+
+func TestNode_Merge_WithBase_EditedAndDeleted(t *testing.T) {
+	base, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	current := base.Clone()
+	_, err = current.RemoveNode("0", "id_C")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	incoming := base.Clone()
+	c, _ := incoming.FindNode("id_C")
+	c.Name = "C2"
+
+	merged, conflicts, err := current.Merge(incoming, base)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.Conflict{{Id: "id_C", Name: "C2", Field: graph.DeletedField, Base: "0", Incoming: "0"}}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("The conflicts do not match. Expected %v, got %v", expected, conflicts)
+		return
+	}
+	if _, err := merged.FindNode("id_C"); err == nil {
+		t.Errorf("The deleted node was restored")
+	}
+}
+
+func TestNode_Merge_FailsNil(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, _, err = root.Merge(nil, nil)
+	if err == nil {
+		t.Errorf("Merge did not return an error")
+		return
+	}
+	if err.Error() != "incoming cannot be nil" {
+		t.Errorf("The error message does not match. Expected \"incoming cannot be nil\", got %s", err)
+	}
+}
@@ -5,52 +5,64 @@ import (
 	"fmt"
 )
 
-// MoveNode moves a node from its parent to a new parent
+// MoveNode moves a node from its parent to a new parent. A node cannot be moved to a node reachable from it, either
+// through its children or through its references, since that would create a cycle
 func (n *Node) MoveNode(parentId, targetId, newParentId string) (*Node, error) {
+	return n.moveNode(newIndex(n), parentId, targetId, newParentId)
+}
+
+// moveNode moves a node of the graph whose index is given from its parent to a new parent
+func (n *Node) moveNode(x *index, parentId, targetId, newParentId string) (*Node, error) {
 	// trivial case, nothing to be done
 	if parentId == newParentId {
 		return n, nil
 	}
 
-	var parent *Node
+	nodes := x.nodes
+	parent, found := nodes[parentId]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", parentId))
+	}
+	parent, err := resolve(parent, nodes)
+	if err != nil {
+		return nil, err
+	}
 	var target *Node
-	var newParent *Node
-
-	for _, node := range n.Traverse() {
-		if node.Id == parentId {
-			parent = node
-			for _, child := range node.Children {
-				if child.Id == targetId {
-					// newParent cannot be a target's child
-					for _, c := range child.Children {
-						if c.Id == newParentId {
-							msg := fmt.Sprintf("the node %q cannot be moved to its child %q", targetId, newParentId)
-							return nil, errors.NewIllegalArgumentError(msg)
-						}
-					}
-					target = child
-				}
-			}
-		} else if node.Id == newParentId {
-			newParent = node
-		}
-		if parent != nil && target != nil && newParent != nil {
-			break
+	for _, child := range parent.Children {
+		if child.Id == targetId {
+			target = child
 		}
 	}
-
-	if parent == nil {
-		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", parentId))
-	}
 	if target == nil {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", targetId))
 	}
-	if newParent == nil {
+	newParent, found := nodes[newParentId]
+	if !found {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the new parent node with ID %q was not found", newParentId))
 	}
+	newParent, err = resolve(newParent, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if newParent == parent {
+		return n, nil
+	}
+
+	// newParent cannot be reachable from the target
+	if reachable(target, nodes)[newParent.Id] {
+		for _, child := range target.Children {
+			if child.Id == newParentId {
+				msg := fmt.Sprintf("the node %q cannot be moved to its child %q", targetId, newParentId)
+				return nil, errors.NewIllegalArgumentError(msg)
+			}
+		}
+		msg := fmt.Sprintf("the node %q cannot be moved to %q without creating a cycle", targetId, newParentId)
+		return nil, errors.NewIllegalArgumentError(msg)
+	}
 
 	// add the target to the new parent's children
 	newParent.Children = append(newParent.Children, target)
+	x.parents[target.Id] = newParent
 
 	// remove the target from the parent's children
 	children := make([]*Node, 0)
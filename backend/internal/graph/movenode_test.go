@@ -110,3 +110,40 @@ func TestNode_MoveNode_FailsNewParentNotFound(t *testing.T) {
 		t.Errorf("The error message does not match. Expected \"the new parent node with ID \"id_Z\" was not found\", got %s", err)
 	}
 }
+
+func TestNode_MoveNode_FailsMoveToDescendant(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.MoveNode("0", "id_D", "id_H")
+	if err == nil {
+		t.Errorf("MoveNode did not return an error")
+		return
+	}
+	if err.Error() != "the node \"id_D\" cannot be moved to \"id_H\" without creating a cycle" {
+		t.Errorf("The error message does not match. Expected \"the node \"id_D\" cannot be moved to \"id_H\" without creating a cycle\", got %s", err)
+	}
+}
+
+func TestNode_MoveNode_FailsCycleThroughReference(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.LinkNode("id_B", "id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.MoveNode("0", "id_B", "id_I")
+	if err == nil {
+		t.Errorf("MoveNode did not return an error")
+		return
+	}
+	if err.Error() != "the node \"id_B\" cannot be moved to \"id_I\" without creating a cycle" {
+		t.Errorf("The error message does not match. Expected \"the node \"id_B\" cannot be moved to \"id_I\" without creating a cycle\", got %s", err)
+	}
+}
@@ -11,16 +11,19 @@ const (
 	division     = NodeType("division")
 	lexeme       = NodeType("lexeme")
 	opposition   = NodeType("opposition")
+	reference    = NodeType("reference")
 )
 
 type NodeType string
 
-// Node represents a node of a graph which can be traversed using the Depth-First Search algorithm
+// Node represents a node of a graph which can be traversed using the Depth-First Search algorithm.
+// A REFERENCE node stands for the node whose ID is Ref, so that a node can be shared by several parents
 type Node struct {
 	Id         string            `json:"id"`
 	Name       string            `json:"name"`
 	Type       NodeType          `json:"type"`
 	Color      string            `json:"color"`
+	Ref        string            `json:"ref,omitempty"`
 	Properties map[string]string `json:"properties"`
 	Children   []*Node           `json:"children"`
 }
@@ -40,6 +43,29 @@ func NewOpposition(id, name, color string) (*Node, error) {
 	return newNode(id, name, color, opposition)
 }
 
+// NewReference creates a new REFERENCE node to the given node
+func NewReference(id string, target *Node) (*Node, error) {
+	if target == nil {
+		return nil, errors.NewIllegalArgumentError("target cannot be nil")
+	}
+	if target.IsReference() {
+		return nil, errors.NewIllegalArgumentError("target cannot be a reference")
+	}
+	if id == "" {
+		return nil, errors.NewIllegalArgumentError("id cannot be empty")
+	}
+	// the name, color and properties mirror the target's as they are, like when the references are synchronized
+	return &Node{
+		Id:         id,
+		Name:       target.Name,
+		Color:      target.Color,
+		Type:       reference,
+		Ref:        target.Id,
+		Properties: target.Properties,
+		Children:   make([]*Node, 0),
+	}, nil
+}
+
 // NewNode creates a new node
 func newNode(id, name, color string, nodeType NodeType) (*Node, error) {
 	if id == "" {
@@ -70,6 +96,11 @@ func (n *Node) String() (string, error) {
 	return string(bytes), nil
 }
 
+// IsReference returns true if this node is a reference to another node
+func (n *Node) IsReference() bool {
+	return n.Type == reference
+}
+
 // GetProperty returns a property
 func (n *Node) GetProperty(name string) string {
 	return n.Properties[name]
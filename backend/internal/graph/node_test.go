@@ -42,6 +42,18 @@ func TestNewNode_Fails(t *testing.T) {
 	}
 }
 
+func TestNewReference_Success(t *testing.T) {
+	target := &graph.Node{Id: "id_T", Name: " ", Type: "lexeme", Properties: map[string]string{"p": "v"}}
+	node, err := graph.NewReference("id_R", target)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if node.Ref != "id_T" || node.Name != " " || node.Color != "" || node.Properties["p"] != "v" || !node.IsReference() {
+		t.Errorf("The reference does not mirror its target: %v", node)
+	}
+}
+
 func TestNode_String(t *testing.T) {
 	root, _, err := provisionNodes()
 	if err != nil {
@@ -0,0 +1,374 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// Operation is a mutation of the graph which can be reverted. Operations refer to nodes by ID, so that they can be
+// applied and reverted on any copy of the graph
+type Operation interface {
+	// apply applies the operation to the graph, keeping the given index of the graph up to date, and returns its root
+	// node. The graph is left unchanged if the operation cannot be applied
+	apply(root *Node, x *index) (*Node, error)
+	// revert reverts the operation, which must be the latest one applied to the graph, keeping the given index of the
+	// graph up to date, and returns its root node
+	revert(root *Node, x *index) (*Node, error)
+	// String describes the operation
+	String() string
+}
+
+// NewAddOperation returns the operation adding a node to the given parent
+func NewAddOperation(parent string, node *Node) Operation {
+	return &addOperation{parent: parent, node: node}
+}
+
+// NewUpdateOperation returns the operation updating a child of the given parent
+func NewUpdateOperation(parent string, node *Node) Operation {
+	return &updateOperation{parent: parent, node: node}
+}
+
+// NewRemoveOperation returns the operation removing a node from the given parent
+func NewRemoveOperation(parent, target string) Operation {
+	return &removeOperation{parent: parent, target: target}
+}
+
+// NewMoveOperation returns the operation moving a node from its parent to a new parent
+func NewMoveOperation(parent, target, newParent string) Operation {
+	return &moveOperation{parent: parent, target: target, newParent: newParent}
+}
+
+// NewLinkOperation returns the operation adding a reference to the target node to the given parent
+func NewLinkOperation(parent, target string) Operation {
+	return &linkOperation{parent: parent, target: target}
+}
+
+// NewReplaceOperation returns an operation applying an arbitrary function to a copy of the graph, which is reverted by
+// restoring the graph it replaced
+func NewReplaceOperation(description string, fn func(root *Node) (*Node, error)) Operation {
+	return &replaceOperation{description: description, fn: fn}
+}
+
+// addOperation adds a node to a parent and is reverted by removing it
+type addOperation struct {
+	parent string
+	node   *Node
+}
+
+func (o *addOperation) apply(root *Node, x *index) (*Node, error) {
+	if o.node == nil {
+		return nil, errors.NewIllegalArgumentError("newNode cannot be nil")
+	}
+	// the IDs must be known to revert the operation
+	if o.node.Id == "" {
+		o.node.Id = uuid.New().String()
+	}
+	o.node.Traverse()
+	return root.addNode(x, o.parent, o.node.Clone())
+}
+
+func (o *addOperation) revert(root *Node, x *index) (*Node, error) {
+	_, err := root.removeNode(x, o.parent, o.node.Id)
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func (o *addOperation) String() string {
+	name := ""
+	if o.node != nil {
+		name = o.node.Name
+	}
+	return fmt.Sprintf("add the node %q to %q", name, o.parent)
+}
+
+// updateOperation updates a child of a parent and is reverted by restoring its previous fields and by removing the
+// child it added, if any
+type updateOperation struct {
+	parent string
+	node   *Node
+	before *Node
+	added  string
+}
+
+func (o *updateOperation) apply(root *Node, x *index) (*Node, error) {
+	if o.node == nil {
+		return nil, errors.NewIllegalArgumentError("targetNode cannot be nil")
+	}
+	// the ID of the new child must be known to revert the operation
+	o.node.Traverse()
+	nodes := x.nodes
+	child, found := nodes[o.node.Id]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", o.node.Id))
+	}
+	target, err := resolve(child, nodes)
+	if err != nil {
+		return nil, err
+	}
+	before := *target
+	before.Properties = make(map[string]string)
+	for key, value := range target.Properties {
+		before.Properties[key] = value
+	}
+	before.Children = nil
+
+	root, err = root.updateNode(x, o.parent, o.node.Clone())
+	if err != nil {
+		return nil, err
+	}
+	o.before = &before
+	o.added = ""
+	if len(o.node.Children) == 1 {
+		o.added = o.node.Children[0].Id
+	}
+	return root, nil
+}
+
+func (o *updateOperation) revert(root *Node, x *index) (*Node, error) {
+	if o.added != "" {
+		_, err := root.removeNode(x, o.before.Id, o.added)
+		if err != nil {
+			return nil, err
+		}
+	}
+	target, err := x.findNode(o.before.Id)
+	if err != nil {
+		return nil, err
+	}
+	target.Name = o.before.Name
+	target.Type = o.before.Type
+	target.Color = o.before.Color
+	target.Properties = o.before.Properties
+	x.syncReferencesTo(target)
+	return root, nil
+}
+
+func (o *updateOperation) String() string {
+	name := ""
+	if o.node != nil {
+		name = o.node.Name
+	}
+	return fmt.Sprintf("update the node %q", name)
+}
+
+// removeOperation removes a node and is reverted by inserting it back at its original index under its original
+// parent and by restoring the references replaced by the promoted nodes
+type removeOperation struct {
+	parent  string
+	target  string
+	removal *removal
+}
+
+func (o *removeOperation) apply(root *Node, x *index) (*Node, error) {
+	r, err := root.removeNode(x, o.parent, o.target)
+	if err != nil {
+		return nil, err
+	}
+	o.removal = r
+	return root, nil
+}
+
+func (o *removeOperation) revert(root *Node, x *index) (*Node, error) {
+	// work on copies, so that the removal can be reverted again if the graph is restored
+	removed := o.removal.node.Clone()
+	nodes := x.nodes
+	detached := nodesById(removed)
+	for i := len(o.removal.promotions) - 1; i >= 0; i-- {
+		p := o.removal.promotions[i]
+		parent, found := nodes[p.parent]
+		if !found || p.index >= len(parent.Children) || parent.Children[p.index].Id != p.node {
+			return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the promoted node with ID %q was not found", p.node))
+		}
+		promoted := parent.Children[p.index]
+		parent.Children[p.index] = p.reference.Clone()
+		x.remove(promoted)
+		x.add(parent, parent.Children[p.index])
+		if p.origin == "" {
+			removed = promoted
+		} else {
+			origin, found := detached[p.origin]
+			if !found {
+				return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the node with ID %q was not found", p.origin))
+			}
+			insertChild(origin, p.originIndex, promoted)
+		}
+		for id, node := range nodesById(promoted) {
+			detached[id] = node
+		}
+	}
+	parent, found := nodes[o.removal.parent]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", o.removal.parent))
+	}
+	insertChild(parent, o.removal.index, removed)
+	x.add(parent, removed)
+	x.syncReferences()
+	return root, nil
+}
+
+func (o *removeOperation) String() string {
+	return fmt.Sprintf("delete the node %q from %q", o.target, o.parent)
+}
+
+// moveOperation moves a node to a new parent and is reverted by moving it back at its original index under its
+// original parent
+type moveOperation struct {
+	parent    string
+	target    string
+	newParent string
+	from      string
+	to        string
+	index     int
+}
+
+func (o *moveOperation) apply(root *Node, x *index) (*Node, error) {
+	nodes := x.nodes
+	o.from, o.to, o.index = "", "", -1
+	if parent, found := nodes[o.parent]; found {
+		if parent, err := resolve(parent, nodes); err == nil {
+			o.from = parent.Id
+			o.index = childIndex(parent, o.target)
+		}
+	}
+	if newParent, found := nodes[o.newParent]; found {
+		if newParent, err := resolve(newParent, nodes); err == nil {
+			o.to = newParent.Id
+		}
+	}
+	return root.moveNode(x, o.parent, o.target, o.newParent)
+}
+
+func (o *moveOperation) revert(root *Node, x *index) (*Node, error) {
+	if o.from == o.to {
+		return root, nil
+	}
+	newParent, found := x.nodes[o.to]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the new parent node with ID %q was not found", o.to))
+	}
+	parent, found := x.nodes[o.from]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", o.from))
+	}
+	target := detachChild(newParent, o.target)
+	if target == nil {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", o.target))
+	}
+	insertChild(parent, o.index, target)
+	x.parents[target.Id] = parent
+	return root, nil
+}
+
+func (o *moveOperation) String() string {
+	return fmt.Sprintf("move the node %q from %q to %q", o.target, o.parent, o.newParent)
+}
+
+// linkOperation adds a reference to a node and is reverted by removing the reference
+type linkOperation struct {
+	parent    string
+	target    string
+	from      string
+	reference string
+}
+
+func (o *linkOperation) apply(root *Node, x *index) (*Node, error) {
+	// the reference keeps its ID when the operation is applied again
+	if o.reference == "" {
+		o.reference = uuid.New().String()
+	}
+	parent, err := root.linkNode(x, o.parent, o.target, o.reference)
+	if err != nil {
+		return nil, err
+	}
+	o.from = parent.Id
+	return root, nil
+}
+
+func (o *linkOperation) revert(root *Node, x *index) (*Node, error) {
+	parent, err := x.findNode(o.from)
+	if err != nil {
+		return nil, err
+	}
+	reference := detachChild(parent, o.reference)
+	if reference == nil {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the reference with ID %q was not found", o.reference))
+	}
+	x.remove(reference)
+	return root, nil
+}
+
+func (o *linkOperation) String() string {
+	return fmt.Sprintf("link the node %q to %q", o.target, o.parent)
+}
+
+// replaceOperation applies an arbitrary function to a copy of the graph and is reverted by restoring the graph it
+// replaced, which is replaced again when the operation is applied again
+type replaceOperation struct {
+	description string
+	fn          func(root *Node) (*Node, error)
+	before      *Node
+	after       *Node
+}
+
+func (o *replaceOperation) apply(root *Node, x *index) (*Node, error) {
+	after := o.after
+	if after == nil {
+		// the function may modify the copy in any way, even if it fails
+		var err error
+		after, err = o.fn(root.Clone())
+		if err != nil {
+			return nil, err
+		}
+	}
+	o.before, o.after = root, nil
+	x.reindex(after)
+	return after, nil
+}
+
+func (o *replaceOperation) revert(root *Node, x *index) (*Node, error) {
+	before := o.before
+	o.before, o.after = nil, root
+	x.reindex(before)
+	return before, nil
+}
+
+func (o *replaceOperation) String() string {
+	return o.description
+}
+
+// childIndex returns the index of the child with the given ID, or -1
+func childIndex(parent *Node, id string) int {
+	for i, child := range parent.Children {
+		if child.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// detachChild removes the child with the given ID from the parent's children and returns it
+func detachChild(parent *Node, id string) *Node {
+	i := childIndex(parent, id)
+	if i < 0 {
+		return nil
+	}
+	child := parent.Children[i]
+	children := make([]*Node, 0, len(parent.Children)-1)
+	children = append(children, parent.Children[:i]...)
+	parent.Children = append(children, parent.Children[i+1:]...)
+	return child
+}
+
+// insertChild inserts a child at the given index of the parent's children, or appends it if the index is out of range
+func insertChild(parent *Node, index int, child *Node) {
+	if index < 0 || index > len(parent.Children) {
+		index = len(parent.Children)
+	}
+	children := make([]*Node, 0, len(parent.Children)+1)
+	children = append(children, parent.Children[:index]...)
+	children = append(children, child)
+	parent.Children = append(children, parent.Children[index:]...)
+}
@@ -0,0 +1,323 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// graphString returns the JSON string of the graph
+func graphString(t *testing.T, g *graph.Graph) string {
+	var s string
+	err := g.View(func(root *graph.Node) error {
+		var err error
+		s, err = root.String()
+		return err
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return s
+}
+
+// checkUndoRedo undoes the latest operation, expecting the original graph, and redoes it, expecting the changed graph
+func checkUndoRedo(t *testing.T, g *graph.Graph, original string) {
+	changed := graphString(t, g)
+	checkIndex(t, g, original)
+	err := g.Undo()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if s := graphString(t, g); s != original {
+		t.Errorf("The undone graph does not match the original one. Expected\n%s\ngot\n%s", original, s)
+		return
+	}
+	checkIndex(t, g, changed)
+	err = g.Redo()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if s := graphString(t, g); s != changed {
+		t.Errorf("The redone graph does not match the changed one. Expected\n%s\ngot\n%s", changed, s)
+		return
+	}
+	checkIndex(t, g, original)
+}
+
+func TestGraph_Undo_AddNode(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	node := &graph.Node{Name: "K", Children: []*graph.Node{{Name: "L"}}}
+	err := g.Do(graph.NewAddOperation("id_G", node))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_UpdateNode(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	node := &graph.Node{Id: "id_G", Name: "G2", Color: red, Type: "division", Children: []*graph.Node{{Name: "K"}}}
+	err := g.Do(graph.NewUpdateOperation("id_D", node))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_RemoveNode(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	err := g.Do(graph.NewRemoveOperation("0", "id_D"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_RemoveReferencedNode(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Do(graph.NewLinkOperation("id_B", "id_H"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Do(graph.NewLinkOperation("id_C", "id_G"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	original := graphString(t, g)
+	err = g.Do(graph.NewRemoveOperation("0", "id_D"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_RemoveNestedReferencedNode(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Do(graph.NewLinkOperation("id_B", "id_G"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Do(graph.NewLinkOperation("id_H", "id_F"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	original := graphString(t, g)
+	err = g.Do(graph.NewRemoveOperation("0", "id_D"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	// G takes the place of its reference, then F the place of its reference in the subtree of G
+	path, err := g.FindPath("id_F")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	ids := make([]string, len(path))
+	for i, step := range path {
+		ids[i] = step.Id
+	}
+	if !reflect.DeepEqual(ids, []string{"0", "id_B", "id_G", "id_H", "id_F"}) {
+		t.Errorf("Unexpected path %v", ids)
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_RemoveReferencedNodeWithoutColor(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Update("clear the color", func(root *graph.Node) (*graph.Node, error) {
+		node, err := root.FindNode("id_G")
+		if err != nil {
+			return nil, err
+		}
+		node.Color = ""
+		return root, nil
+	})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Do(graph.NewLinkOperation("id_B", "id_G"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	original := graphString(t, g)
+	err = g.Do(graph.NewRemoveOperation("id_D", "id_G"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_MoveNode(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	err := g.Do(graph.NewMoveOperation("id_D", "id_F", "id_B"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_LinkNode(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	err := g.Do(graph.NewLinkOperation("id_B", "id_G"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_Update(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	err := g.Clear()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	checkUndoRedo(t, g, original)
+}
+
+func TestGraph_Undo_Sequence(t *testing.T) {
+	g := provisionGraph(t)
+	original := graphString(t, g)
+	operations := []graph.Operation{
+		graph.NewMoveOperation("id_D", "id_G", "id_B"),
+		graph.NewRemoveOperation("0", "id_D"),
+		graph.NewAddOperation("id_G", &graph.Node{Id: "id_K", Name: "K"}),
+	}
+	for _, operation := range operations {
+		err := g.Do(operation)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	}
+	for range operations {
+		err := g.Undo()
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+	}
+	if s := graphString(t, g); s != original {
+		t.Errorf("The undone graph does not match the original one. Expected\n%s\ngot\n%s", original, s)
+	}
+	revisions := g.Revisions()
+	if revisions[len(revisions)-1].Operation != "undo: move the node \"id_G\" from \"id_D\" to \"id_B\"" {
+		t.Errorf("Unexpected revision %v", revisions[len(revisions)-1])
+	}
+}
+
+func TestGraph_Undo_Nothing(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Undo()
+	if err == nil {
+		t.Errorf("Undo did not return an error")
+		return
+	}
+	if err.Error() != "there is nothing to undo" {
+		t.Errorf("The error message does not match. Expected \"there is nothing to undo\", got %s", err)
+	}
+}
+
+func TestGraph_Redo_ClearedByNewOperation(t *testing.T) {
+	g := provisionGraph(t)
+	err := g.Do(graph.NewRemoveOperation("0", "id_B"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Undo()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Do(graph.NewRemoveOperation("0", "id_C"))
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	err = g.Redo()
+	if err == nil {
+		t.Errorf("Redo did not return an error")
+		return
+	}
+	if err.Error() != "there is nothing to redo" {
+		t.Errorf("The error message does not match. Expected \"there is nothing to redo\", got %s", err)
+	}
+}
+
+func TestGraph_Do_FailsSave(t *testing.T) {
+	for _, operation := range []graph.Operation{
+		graph.NewAddOperation("id_G", &graph.Node{Id: "id_K", Name: "K"}),
+		graph.NewUpdateOperation("id_D", &graph.Node{Id: "id_G", Name: "G bis", Type: "division"}),
+		graph.NewRemoveOperation("0", "id_D"),
+		graph.NewMoveOperation("id_D", "id_G", "id_B"),
+		graph.NewLinkOperation("id_C", "id_H"),
+		graph.NewReplaceOperation("clear", func(root *graph.Node) (*graph.Node, error) {
+			root.Children = nil
+			return root, nil
+		}),
+	} {
+		g := provisionGraph(t)
+		err := g.Do(graph.NewLinkOperation("id_B", "id_G"))
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		original := graphString(t, g)
+		filename := g.Filename
+		g.Filename = filepath.Join(t.TempDir(), "missing", "graph.json")
+		err = g.Do(operation)
+		if err == nil {
+			t.Errorf("%s: Do did not return an error", operation)
+			continue
+		}
+		if s := graphString(t, g); s != original {
+			t.Errorf("%s: the graph was not restored. Expected\n%s\ngot\n%s", operation, original, s)
+			continue
+		}
+		checkIndex(t, g, original)
+
+		g.Filename = filename
+		err = g.Do(operation)
+		if err != nil {
+			t.Errorf(err.Error())
+			continue
+		}
+		changed := graphString(t, g)
+		g.Filename = filepath.Join(t.TempDir(), "missing", "graph.json")
+		err = g.Undo()
+		if err == nil {
+			t.Errorf("%s: Undo did not return an error", operation)
+			continue
+		}
+		if s := graphString(t, g); s != changed {
+			t.Errorf("%s: the graph was not restored. Expected\n%s\ngot\n%s", operation, changed, s)
+			continue
+		}
+		checkIndex(t, g, original)
+	}
+}
@@ -0,0 +1,56 @@
+package graph
+
+import "strconv"
+
+// PathStep is a node on the path from the root to another node
+type PathStep struct {
+	Id     string   `json:"id"`
+	Name   string   `json:"name"`
+	Type   NodeType `json:"type"`
+	Number string   `json:"number"`
+}
+
+// walkOutline recursively visits the graph using the Depth-First Search algorithm, calling fn with each node and the
+// counters of its outline number. fn must not retain the counters
+func walkOutline(node *Node, counters []int, fn func(node *Node, counters []int)) {
+	fn(node, counters)
+	if len(node.Children) > 0 {
+		counters = append(counters, 0)
+	}
+	for _, child := range node.Children {
+		counters[len(counters)-1]++
+		walkOutline(child, counters, fn)
+	}
+}
+
+// walkPaths visits the graph like walkOutline, calling fn with each node and the path from the root to it, the node
+// being the last step. fn must not retain the path
+func walkPaths(root *Node, fn func(node *Node, path []PathStep)) {
+	var path []PathStep
+	walkOutline(root, []int{1}, func(node *Node, counters []int) {
+		path = append(path[:len(counters)-1], PathStep{
+			Id:     node.Id,
+			Name:   node.Name,
+			Type:   node.Type,
+			Number: formatCounters(counters),
+		})
+		fn(node, path)
+	})
+}
+
+// outlineNumbers returns the outline number of each node, as assigned by Stringify
+func outlineNumbers(root *Node) map[string]string {
+	numbers := make(map[string]string)
+	walkOutline(root, []int{1}, func(node *Node, counters []int) {
+		numbers[node.Id] = formatCounters(counters)
+	})
+	return numbers
+}
+
+func formatCounters(counters []int) string {
+	s := ""
+	for i := 0; i < len(counters); i++ {
+		s += "." + strconv.Itoa(counters[i])
+	}
+	return s[1:]
+}
@@ -13,5 +13,6 @@ func (n *Node) Parse(bytes []byte) (*Node, error) {
 		return nil, errors.NewParsingError(fmt.Sprintf("failed to parse the node [%s]", err))
 	}
 	n.Traverse()
+	syncReferences(n)
 	return n, nil
 }
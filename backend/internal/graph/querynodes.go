@@ -0,0 +1,317 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Match is a node matching a query, with the path of its ancestors from the root
+type Match struct {
+	PathStep
+	Ancestors []PathStep `json:"ancestors"`
+}
+
+// Query returns the nodes matching the query in Depth-First Search order. A query is a sequence of predicates which
+// must all hold, e.g., `type:division depth>2 prop:source~"De Veritate" hasChild(name="ens mobile")`:
+//   - field:value, field=value and field!=value compare a field with a value, while field~value tests whether it
+//     contains the value. The fields are id, name, type, color, number, the outline number, and depth, the root having
+//     depth 0. IDs and outline numbers are compared as they are, and other text like Search does, ignoring case,
+//     diacritics and the Latin spelling variants
+//   - field>value, field>=value, field<value and field<=value compare numbers
+//   - prop:key tests whether a node has the property, and prop:key=value etc. compare its value
+//   - children(query), parents(query), ancestors(query) and descendants(query) hold if at least one node along the axis
+//     matches the query, and can be compared with a number of nodes, e.g., parents(type:division)>1. Without a query,
+//     they count every node along the axis. hasChild, hasParent, hasAncestor and hasDescendant are synonyms
+//   - a value alone matches the nodes whose name contains it
+//
+// Predicates can be negated with NOT or -, combined with OR and grouped with parentheses. Values containing spaces or
+// operators are quoted. The axes follow the references, so that a referenced node is a child of the parents of its
+// references, which are not matched themselves
+func (n *Node) Query(query string) ([]Match, error) {
+	predicate, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	c := newQueryContext(n)
+	matches := make([]Match, 0)
+	walkPaths(n, func(node *Node, path []PathStep) {
+		if !node.IsReference() && predicate.match(c, node) {
+			ancestors := append([]PathStep{}, path[:len(path)-1]...)
+			matches = append(matches, Match{PathStep: path[len(path)-1], Ancestors: ancestors})
+		}
+	})
+	return matches, nil
+}
+
+// queryContext holds the outline numbers, the depths and the relations of the nodes a query is matched against, where
+// references are replaced with the referenced nodes, and the memoized results and counts of the predicates
+type queryContext struct {
+	numbers  map[*Node]string
+	depths   map[*Node]int
+	children map[*Node][]*Node
+	parents  map[*Node][]*Node
+	branches map[string]map[*Node]bool
+	results  map[queryPredicate]map[*Node]bool
+	counts   map[*axisPredicate]map[*Node]int
+}
+
+// newQueryContext returns the context of a query on the given node and its descendants
+func newQueryContext(root *Node) *queryContext {
+	c := &queryContext{
+		numbers:  make(map[*Node]string),
+		depths:   make(map[*Node]int),
+		children: make(map[*Node][]*Node),
+		parents:  make(map[*Node][]*Node),
+		branches: map[string]map[*Node]bool{ancestorsAxis: {}, descendantsAxis: {}},
+		results:  make(map[queryPredicate]map[*Node]bool),
+		counts:   make(map[*axisPredicate]map[*Node]int),
+	}
+	nodes := nodesById(root)
+	walkPaths(root, func(node *Node, path []PathStep) {
+		c.numbers[node] = path[len(path)-1].Number
+		c.depths[node] = len(path) - 1
+		for _, child := range node.Children {
+			child, err := resolve(child, nodes)
+			if err != nil {
+				continue
+			}
+			c.children[node] = append(c.children[node], child)
+			c.parents[child] = append(c.parents[child], node)
+		}
+	})
+	return c
+}
+
+// next returns the nodes one step along the given axis, i.e., the parents along the ancestors and the children along
+// the descendants
+func (c *queryContext) next(axis string) map[*Node][]*Node {
+	if axis == parentsAxis || axis == ancestorsAxis {
+		return c.parents
+	}
+	return c.children
+}
+
+// relatives returns the distinct nodes along the given axis from the given node
+func (c *queryContext) relatives(axis string, node *Node) []*Node {
+	next := c.next(axis)
+	if axis == childrenAxis || axis == parentsAxis {
+		return next[node]
+	}
+	var relatives []*Node
+	visited := make(map[*Node]bool)
+	stack := append([]*Node{}, next[node]...)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		relatives = append(relatives, current)
+		stack = append(stack, next[current]...)
+	}
+	return relatives
+}
+
+// branched returns true if a node along the given axis from the given node, or the node itself along the ancestors,
+// has several parents, i.e., is referenced, or is the given node again along a cycle, so that some nodes may be reached
+// along several paths
+func (c *queryContext) branched(axis string, node *Node) bool {
+	branches := c.branches[axis]
+	if result, found := branches[node]; found {
+		return result
+	}
+	// a node which is being checked is only reached again along a cycle
+	branches[node] = true
+	result := false
+	if axis == ancestorsAxis {
+		result = len(c.parents[node]) > 1
+	}
+	for _, relative := range c.next(axis)[node] {
+		if len(c.parents[relative]) > 1 || c.branched(axis, relative) {
+			result = true
+		}
+	}
+	branches[node] = result
+	return result
+}
+
+// count returns the number of distinct nodes along the axis of the given predicate from the given node which match its
+// predicate. Unless nodes may be reached along several paths, the ancestors and the descendants are counted from the
+// counts of the parents and the children, so that counting them for every node does not traverse the graph each time
+func (c *queryContext) count(p *axisPredicate, node *Node) int {
+	counts, found := c.counts[p]
+	if !found {
+		counts = make(map[*Node]int)
+		c.counts[p] = counts
+	}
+	if count, found := counts[node]; found {
+		return count
+	}
+	// the nodes along a cycle are branched and counted without recursion, yet a node being counted must not be recounted
+	counts[node] = 0
+	count := 0
+	if p.axis == childrenAxis || p.axis == parentsAxis || c.branched(p.axis, node) {
+		for _, relative := range c.relatives(p.axis, node) {
+			if c.matches(p.predicate, relative) {
+				count++
+			}
+		}
+	} else {
+		for _, relative := range c.next(p.axis)[node] {
+			count += c.count(p, relative)
+			if c.matches(p.predicate, relative) {
+				count++
+			}
+		}
+	}
+	counts[node] = count
+	return count
+}
+
+// matches returns true if the given node matches the given predicate, which is matched once per node
+func (c *queryContext) matches(predicate queryPredicate, node *Node) bool {
+	results, found := c.results[predicate]
+	if !found {
+		results = make(map[*Node]bool)
+		c.results[predicate] = results
+	}
+	result, found := results[node]
+	if !found {
+		result = predicate.match(c, node)
+		results[node] = result
+	}
+	return result
+}
+
+// queryPredicate is a predicate of a query. The predicates are pointers, so that their results can be memoized
+type queryPredicate interface {
+	match(c *queryContext, node *Node) bool
+}
+
+// andPredicate holds if all its predicates hold
+type andPredicate struct {
+	predicates []queryPredicate
+}
+
+func (p *andPredicate) match(c *queryContext, node *Node) bool {
+	for _, predicate := range p.predicates {
+		if !predicate.match(c, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// orPredicate holds if any of its predicates holds
+type orPredicate struct {
+	predicates []queryPredicate
+}
+
+func (p *orPredicate) match(c *queryContext, node *Node) bool {
+	for _, predicate := range p.predicates {
+		if predicate.match(c, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// notPredicate holds if its predicate does not
+type notPredicate struct {
+	predicate queryPredicate
+}
+
+func (p *notPredicate) match(c *queryContext, node *Node) bool {
+	return !p.predicate.match(c, node)
+}
+
+// fieldPredicate compares a field of a node, or the property with the given key, with a value. A property without an
+// operator holds if the node has the property
+type fieldPredicate struct {
+	field    string
+	key      string
+	operator string
+	value    string
+}
+
+func (p *fieldPredicate) match(c *queryContext, node *Node) bool {
+	var value string
+	switch p.field {
+	case "id":
+		return compareValues(node.Id, p.operator, p.value, true)
+	case "number":
+		return compareValues(c.numbers[node], p.operator, p.value, true)
+	case NameField:
+		value = node.Name
+	case "type":
+		value = string(node.Type)
+	case "color":
+		value = node.Color
+	case "depth":
+		value = strconv.Itoa(c.depths[node])
+	case propField:
+		property, found := node.Properties[p.key]
+		if p.operator == "" {
+			return found && property != ""
+		}
+		value = property
+	}
+	return compareValues(value, p.operator, p.value, false)
+}
+
+// axisPredicate compares the number of nodes along an axis which match its predicate with a count
+type axisPredicate struct {
+	axis      string
+	predicate queryPredicate
+	operator  string
+	count     int
+}
+
+func (p *axisPredicate) match(c *queryContext, node *Node) bool {
+	return compareNumbers(float64(c.count(p, node)), p.operator, float64(p.count))
+}
+
+// compareValues compares a value with the value of a predicate, as numbers if both are numbers and the operator is not
+// ~, and else as text normalized like Search does. Exact values, i.e., IDs and outline numbers, are compared as they are
+func compareValues(value, operator, expected string, exact bool) bool {
+	if operator != "~" && !exact {
+		a, errA := strconv.ParseFloat(value, 64)
+		b, errB := strconv.ParseFloat(expected, 64)
+		if errA == nil && errB == nil {
+			return compareNumbers(a, operator, b)
+		}
+	}
+	if !exact {
+		value, expected = normalizeLatin(value), normalizeLatin(expected)
+	}
+	switch operator {
+	case ":", "=":
+		return value == expected
+	case "!=":
+		return value != expected
+	case "~":
+		return strings.Contains(value, expected)
+	}
+	// the other operators only compare numbers
+	return false
+}
+
+// compareNumbers compares two numbers
+func compareNumbers(a float64, operator string, b float64) bool {
+	switch operator {
+	case ":", "=":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"reflect"
+	"testing"
+)
+
+// provisionQueryNodes returns the test nodes where B and G are divisions, H has a child K and I is also a child of B
+func provisionQueryNodes(t *testing.T) *graph.Node {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, id := range []string{"id_B", "id_G"} {
+		node, err := root.FindNode(id)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		node.Type = "division"
+	}
+	h, err := root.FindNode("id_H")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	h.SetProperty("source", "Quaestiones disputatae de veritate, q. 1")
+	k, err := graph.NewLexeme("id_K", "ēns mōbile", "")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	_, err = root.AddNode("id_H", k)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	_, err = root.LinkNode("id_B", "id_I")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return root
+}
+
+func TestNode_Query(t *testing.T) {
+	root := provisionQueryNodes(t)
+	queries := map[string][]string{
+		`type:division depth>2 prop:source~"De Veritate" hasChild(name="ens mobile")`: {"id_H"},
+		"type:lexeme parents(type:division)>1":                                        {"id_I"},
+		"hasAncestor(type:opposition) type:lexeme":                                    {"id_F", "id_K", "id_I"},
+		"descendants>3":                                          {"0", "id_D"},
+		"ancestors()=4":                                          {"id_K", "id_I"},
+		"ancestors(type:division)=2":                             {"id_K", "id_I"},
+		"hasDescendant(id=id_K) NOT depth>1":                     {"0", "id_D"},
+		"-type:lexeme OR depth=0":                                {"0", "id_B", "id_D", "id_G", "id_H"},
+		"children(color:#00FFFF)=2":                              {"id_G"},
+		"prop:p1 prop:p2=XYZ":                                    {"0"},
+		"number:1.3.2.2 OR Ens":                                  {"0", "id_K", "id_I"},
+		"(color=#0000ff AND name!=f) hasParent(type:opposition)": {"id_G"},
+	}
+	for query, expected := range queries {
+		matches, err := root.Query(query)
+		if err != nil {
+			t.Errorf("%s: %s", query, err)
+			continue
+		}
+		actual := make([]string, len(matches))
+		for i, match := range matches {
+			actual[i] = match.Id
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %v, got %v", query, expected, actual)
+		}
+	}
+}
+
+func TestNode_Query_Paths(t *testing.T) {
+	root := provisionQueryNodes(t)
+	matches, err := root.Query("id:id_K")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	expected := []graph.Match{{
+		PathStep: graph.PathStep{Id: "id_K", Name: "ēns mōbile", Type: "lexeme", Number: "1.3.2.1.1"},
+		Ancestors: []graph.PathStep{
+			{Id: "0", Name: "ens", Type: "lexeme", Number: "1"},
+			{Id: "id_D", Name: "D", Type: "opposition", Number: "1.3"},
+			{Id: "id_G", Name: "G", Type: "division", Number: "1.3.2"},
+			{Id: "id_H", Name: "H", Type: "division", Number: "1.3.2.1"},
+		},
+	}}
+	if !reflect.DeepEqual(expected, matches) {
+		t.Errorf("Unexpected matches %v", matches)
+	}
+}
+
+func TestNode_Query_Cycle(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	f, err := root.FindNode("id_F")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	// a reference to the root cannot be linked, but can be uploaded
+	f.Children = append(f.Children, &graph.Node{Id: "id_R", Type: "reference", Ref: "0", Children: make([]*graph.Node, 0)})
+	queries := map[string][]string{
+		"hasDescendant(id=0)":                {"0", "id_D", "id_F"},
+		"hasAncestor(id=id_F) depth=1":       {"id_B", "id_C", "id_D", "id_E"},
+		"ancestors()>2 descendants(id=id_G)": {"0", "id_D", "id_F"},
+	}
+	for query, expected := range queries {
+		matches, err := root.Query(query)
+		if err != nil {
+			t.Errorf("%s: %s", query, err)
+			continue
+		}
+		actual := make([]string, len(matches))
+		for i, match := range matches {
+			actual[i] = match.Id
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %v, got %v", query, expected, actual)
+		}
+	}
+}
+
+func BenchmarkNode_Query(b *testing.B) {
+	g, _ := provisionLargeGraph(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := g.View(func(root *graph.Node) error {
+			_, err := root.Query(`descendants(name="node 99999") ancestors()>1`)
+			return err
+		})
+		if err != nil {
+			b.Fatalf(err.Error())
+		}
+	}
+}
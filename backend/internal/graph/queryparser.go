@@ -0,0 +1,340 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	wordToken = iota
+	stringToken
+	operatorToken
+	openToken
+	closeToken
+	notToken
+	endToken
+)
+
+const (
+	childrenAxis    = "children"
+	parentsAxis     = "parents"
+	ancestorsAxis   = "ancestors"
+	descendantsAxis = "descendants"
+	propField       = "prop"
+)
+
+// queryOperators are the comparison operators of the query language, longest first
+var queryOperators = []string{">=", "<=", "!=", ":", "=", "~", ">", "<"}
+
+// queryAxes maps the names of the axis functions to their axis. The has* functions are true if at least one node along
+// the axis matches, and the other ones count the matching nodes
+var queryAxes = map[string]string{
+	childrenAxis:    childrenAxis,
+	parentsAxis:     parentsAxis,
+	ancestorsAxis:   ancestorsAxis,
+	descendantsAxis: descendantsAxis,
+	"hasChild":      childrenAxis,
+	"hasParent":     parentsAxis,
+	"hasAncestor":   ancestorsAxis,
+	"hasDescendant": descendantsAxis,
+}
+
+// queryFields are the fields of the nodes which can be compared, besides the properties
+var queryFields = map[string]bool{"id": true, "name": true, "type": true, "color": true, "number": true, "depth": true}
+
+// queryToken is a token of a query, whose position is the index of its first character
+type queryToken struct {
+	kind     int
+	value    string
+	position int
+}
+
+// queryParser parses a query into the predicate the matching nodes satisfy
+type queryParser struct {
+	tokens []queryToken
+	next   int
+}
+
+// parseQuery parses a query. The grammar is:
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = unary { [ "AND" ] unary }
+//	unary   = ( "NOT" | "-" ) unary | primary
+//	primary = "(" or ")" | axis "(" [ or ] ")" [ operator value ] | axis operator value
+//	        | "prop" ":" key [ operator value ] | field operator value | value
+//	value   = word | string
+func parseQuery(query string) (queryPredicate, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].kind == endToken {
+		return nil, errors.NewIllegalArgumentError("the query cannot be empty")
+	}
+	p := &queryParser{tokens: tokens}
+	predicate, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != endToken {
+		return nil, p.error(token, fmt.Sprintf("unexpected %q", token.value))
+	}
+	return predicate, nil
+}
+
+// lexQuery splits a query into tokens, ending with an end token
+func lexQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	var tokens []queryToken
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		previous := endToken
+		if len(tokens) > 0 {
+			previous = tokens[len(tokens)-1].kind
+		}
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{openToken, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{closeToken, ")", i})
+			i++
+		case r == '-' && previous != operatorToken:
+			tokens = append(tokens, queryToken{notToken, "-", i})
+			i++
+		case r == '"':
+			var builder strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				builder.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, errors.NewIllegalArgumentError(fmt.Sprintf("invalid query at character %d: unterminated string", i+1))
+			}
+			tokens = append(tokens, queryToken{stringToken, builder.String(), i})
+			i = j + 1
+		default:
+			if operator := queryOperator(runes[i:]); operator != "" {
+				tokens = append(tokens, queryToken{operatorToken, operator, i})
+				i += len(operator)
+				continue
+			}
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune(`()"`, runes[j]) &&
+				queryOperator(runes[j:]) == "" {
+				j++
+			}
+			if j == i {
+				return nil, errors.NewIllegalArgumentError(fmt.Sprintf("invalid query at character %d: unexpected %q", i+1, r))
+			}
+			tokens = append(tokens, queryToken{wordToken, string(runes[i:j]), i})
+			i = j
+		}
+	}
+	return append(tokens, queryToken{endToken, "end of query", len(runes)}), nil
+}
+
+// queryOperator returns the operator the given characters start with, or an empty string
+func queryOperator(runes []rune) string {
+	for _, operator := range queryOperators {
+		if strings.HasPrefix(string(runes[:min(len(runes), 2)]), operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+// or parses predicates separated by OR
+func (p *queryParser) or() (queryPredicate, error) {
+	predicate, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	or := &orPredicate{predicates: []queryPredicate{predicate}}
+	for p.keyword("OR") {
+		p.next++
+		predicate, err = p.and()
+		if err != nil {
+			return nil, err
+		}
+		or.predicates = append(or.predicates, predicate)
+	}
+	if len(or.predicates) == 1 {
+		return or.predicates[0], nil
+	}
+	return or, nil
+}
+
+// and parses a sequence of predicates, optionally separated by AND, up to an OR, a closing parenthesis or the end
+func (p *queryParser) and() (queryPredicate, error) {
+	and := &andPredicate{}
+	for {
+		token := p.peek()
+		if token.kind == endToken || token.kind == closeToken || p.keyword("OR") {
+			break
+		}
+		if p.keyword("AND") {
+			if len(and.predicates) == 0 {
+				return nil, p.error(token, fmt.Sprintf("unexpected %q", token.value))
+			}
+			p.next++
+			// AND must be followed by a predicate
+			token = p.peek()
+			if token.kind == endToken || token.kind == closeToken || p.keyword("OR") || p.keyword("AND") {
+				return nil, p.error(token, fmt.Sprintf("expected a predicate before %q", token.value))
+			}
+		}
+		predicate, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		and.predicates = append(and.predicates, predicate)
+	}
+	switch len(and.predicates) {
+	case 0:
+		token := p.peek()
+		return nil, p.error(token, fmt.Sprintf("expected a predicate before %q", token.value))
+	case 1:
+		return and.predicates[0], nil
+	}
+	return and, nil
+}
+
+// unary parses a negated predicate or a primary one
+func (p *queryParser) unary() (queryPredicate, error) {
+	if p.peek().kind == notToken || p.keyword("NOT") {
+		p.next++
+		predicate, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &notPredicate{predicate: predicate}, nil
+	}
+	return p.primary()
+}
+
+// primary parses a group, an axis function, a comparison or a value matched against the names
+func (p *queryParser) primary() (queryPredicate, error) {
+	token := p.peek()
+	p.next++
+	switch token.kind {
+	case openToken:
+		predicate, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return predicate, p.expect(closeToken, ")")
+	case stringToken:
+		return &fieldPredicate{field: NameField, operator: "~", value: token.value}, nil
+	case wordToken:
+	default:
+		return nil, p.error(token, fmt.Sprintf("unexpected %q", token.value))
+	}
+
+	if axis, found := queryAxes[token.value]; found && (p.peek().kind == openToken || p.peek().kind == operatorToken) {
+		return p.axis(token, axis)
+	}
+	if token.value == propField && p.peek().kind == operatorToken && p.peek().value == ":" {
+		p.next++
+		key := p.peek()
+		if key.kind != wordToken && key.kind != stringToken {
+			return nil, p.error(key, fmt.Sprintf("expected a property key before %q", key.value))
+		}
+		p.next++
+		predicate := &fieldPredicate{field: propField, key: key.value}
+		if p.peek().kind == operatorToken {
+			return p.comparison(predicate)
+		}
+		return predicate, nil
+	}
+	if p.peek().kind == operatorToken {
+		if !queryFields[token.value] {
+			return nil, p.error(token, fmt.Sprintf("unknown field %q", token.value))
+		}
+		return p.comparison(&fieldPredicate{field: token.value})
+	}
+	return &fieldPredicate{field: NameField, operator: "~", value: token.value}, nil
+}
+
+// axis parses the optional predicate of an axis function and the optional comparison of the number of matching nodes
+func (p *queryParser) axis(name queryToken, axis string) (queryPredicate, error) {
+	predicate := &axisPredicate{axis: axis, predicate: &andPredicate{}, operator: ">", count: 0}
+	if p.peek().kind == openToken {
+		p.next++
+		if p.peek().kind != closeToken {
+			var err error
+			predicate.predicate, err = p.or()
+			if err != nil {
+				return nil, err
+			}
+		}
+		err := p.expect(closeToken, ")")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().kind != operatorToken {
+		return predicate, nil
+	}
+	operator := p.peek()
+	if strings.HasPrefix(name.value, "has") || operator.value == "~" {
+		return nil, p.error(operator, fmt.Sprintf("unexpected %q", operator.value))
+	}
+	p.next++
+	value := p.peek()
+	count, err := strconv.Atoi(value.value)
+	if err != nil || value.kind != wordToken {
+		return nil, p.error(value, fmt.Sprintf("expected a number of nodes before %q", value.value))
+	}
+	p.next++
+	predicate.operator, predicate.count = operator.value, count
+	return predicate, nil
+}
+
+// comparison parses the operator and the value of a comparison
+func (p *queryParser) comparison(predicate *fieldPredicate) (queryPredicate, error) {
+	predicate.operator = p.peek().value
+	p.next++
+	value := p.peek()
+	if value.kind != wordToken && value.kind != stringToken {
+		return nil, p.error(value, fmt.Sprintf("expected a value before %q", value.value))
+	}
+	p.next++
+	predicate.value = value.value
+	return predicate, nil
+}
+
+// peek returns the next token without consuming it
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+// keyword returns true if the next token is the given keyword
+func (p *queryParser) keyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == wordToken && token.value == keyword
+}
+
+// expect consumes the next token, which must be of the given kind
+func (p *queryParser) expect(kind int, value string) error {
+	token := p.peek()
+	if token.kind != kind {
+		return p.error(token, fmt.Sprintf("expected %q before %q", value, token.value))
+	}
+	p.next++
+	return nil
+}
+
+// error returns the error describing a syntax error at the given token
+func (p *queryParser) error(token queryToken, message string) error {
+	return errors.NewIllegalArgumentError(fmt.Sprintf("invalid query at character %d: %s", token.position+1, message))
+}
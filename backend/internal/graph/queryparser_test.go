@@ -0,0 +1,40 @@
+package graph_test
+
+import (
+	"backend/internal/graph/errors"
+	"testing"
+)
+
+func TestNode_Query_InvalidSyntax(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	queries := map[string]string{
+		"":                "the query cannot be empty",
+		"colour:red":      `invalid query at character 1: unknown field "colour"`,
+		"(type:division":  `invalid query at character 15: expected ")" before "end of query"`,
+		`name="ens`:       "invalid query at character 6: unterminated string",
+		"prop:":           `invalid query at character 6: expected a property key before "end of query"`,
+		"depth>":          `invalid query at character 7: expected a value before "end of query"`,
+		"hasChild>1":      `invalid query at character 9: unexpected ">"`,
+		"children(a)>two": `invalid query at character 13: expected a number of nodes before "two"`,
+		"ens OR":          `invalid query at character 7: expected a predicate before "end of query"`,
+		"ens AND":         `invalid query at character 8: expected a predicate before "end of query"`,
+		"(ens AND) b":     `invalid query at character 9: expected a predicate before ")"`,
+		"ens AND OR b":    `invalid query at character 9: expected a predicate before "OR"`,
+		"AND ens":         `invalid query at character 1: unexpected "AND"`,
+		"ens )":           `invalid query at character 5: unexpected ")"`,
+	}
+	for query, expected := range queries {
+		_, err := root.Query(query)
+		if _, ok := err.(*errors.IllegalArgumentError); !ok {
+			t.Errorf("%q: unexpected error %v", query, err)
+			continue
+		}
+		if err.Error() != expected {
+			t.Errorf("%q: expected %q, got %q", query, expected, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+)
+
+// nodesById returns the nodes of the graph by ID
+func nodesById(root *Node) map[string]*Node {
+	return newIndex(root).nodes
+}
+
+// resolve returns the node referenced by the given node, if it is a reference, or the node itself
+func resolve(node *Node, nodes map[string]*Node) (*Node, error) {
+	if !node.IsReference() {
+		return node, nil
+	}
+	target, found := nodes[node.Ref]
+	if !found {
+		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the node with ID %q referenced by %q was not found", node.Ref, node.Id))
+	}
+	return target, nil
+}
+
+// reachable returns the IDs of the nodes reachable from the given node following both children and references
+func reachable(node *Node, nodes map[string]*Node) map[string]bool {
+	visited := make(map[string]bool)
+	stack := []*Node{node}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[current.Id] {
+			continue
+		}
+		visited[current.Id] = true
+		stack = append(stack, current.Children...)
+		if target, found := nodes[current.Ref]; found && current.IsReference() {
+			stack = append(stack, target)
+		}
+	}
+	return visited
+}
+
+// checkReferences verifies that the references of the given subtree point to existing nodes which are not references
+// themselves and that they do not create any cycle
+func checkReferences(root, subtree *Node, nodes map[string]*Node) error {
+	references := false
+	for _, node := range subtree.Traverse() {
+		if !node.IsReference() {
+			continue
+		}
+		references = true
+		target, found := nodes[node.Ref]
+		if !found {
+			return errors.NewNodeNotFoundError(fmt.Sprintf("the node with ID %q referenced by %q was not found", node.Ref, node.Id))
+		}
+		if target.IsReference() {
+			return errors.NewIllegalArgumentError(fmt.Sprintf("the node %q cannot reference the reference %q", node.Id, node.Ref))
+		}
+	}
+	// without references, the subtree cannot create any cycle
+	if references && hasCycle(root, nodes) {
+		return errors.NewIllegalArgumentError(fmt.Sprintf("the references of the node %q would create a cycle", subtree.Id))
+	}
+	return nil
+}
+
+// hasCycle returns true if the graph has a cycle following both children and references
+func hasCycle(root *Node, nodes map[string]*Node) bool {
+	return len(cyclicReferences(root, nodes)) > 0
+}
+
+// cyclicReferences returns the references which close a cycle following both children and references, in Depth-First
+// Search order. Since the children form a tree, every cycle goes through one of them at least
+func cyclicReferences(root *Node, nodes map[string]*Node) []*Node {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[*Node]int)
+	cyclic := make([]*Node, 0)
+	var visit func(node *Node)
+	visit = func(node *Node) {
+		states[node] = visiting
+		for _, child := range node.Children {
+			if child != nil && states[child] == 0 {
+				visit(child)
+			}
+		}
+		if target, found := nodes[node.Ref]; found && node.IsReference() {
+			switch states[target] {
+			case visiting:
+				cyclic = append(cyclic, node)
+			case 0:
+				visit(target)
+			}
+		}
+		states[node] = visited
+	}
+	visit(root)
+	return cyclic
+}
+
+// syncReferences copies the name, color and properties of the referenced nodes into their references
+func syncReferences(root *Node) {
+	newIndex(root).syncReferences()
+}
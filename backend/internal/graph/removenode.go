@@ -3,33 +3,124 @@ package graph
 import (
 	"backend/internal/graph/errors"
 	"fmt"
+	"slices"
+	"sort"
 )
 
-// RemoveNode removes a node from the graph
+// removal describes a removed node and the referenced nodes of its subtree which took the place of their references
+type removal struct {
+	parent     string
+	index      int
+	node       *Node
+	promotions []promotion
+}
+
+// promotion describes a node of a removed subtree which replaced its first reference
+type promotion struct {
+	parent      string
+	index       int
+	reference   *Node
+	node        string
+	origin      string
+	originIndex int
+}
+
+// RemoveNode removes a node from the graph. A removed node which is still referenced elsewhere takes the place of its
+// first reference
 func (n *Node) RemoveNode(parent, target string) (*Node, error) {
-	parentFound := false
-	targetFound := false
-	nodes := n.Traverse()
-	for i, parentNode := range nodes {
-		if parentNode.Id == parent {
-			parentFound = true
-			children := make([]*Node, 0)
-			for _, child := range parentNode.Children {
-				if child.Id == target {
-					targetFound = true
-				} else {
-					children = append(children, child)
-				}
-			}
-			nodes[i].Children = children
-			break
-		}
+	_, err := n.removeNode(newIndex(n), parent, target)
+	if err != nil {
+		return nil, err
 	}
-	if !parentFound {
+	return n, nil
+}
+
+// removeNode removes a node from the graph whose index is given and returns what has been removed
+func (n *Node) removeNode(x *index, parent, target string) (*removal, error) {
+	nodes := x.nodes
+	parentNode, found := nodes[parent]
+	if !found {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", parent))
 	}
-	if !targetFound {
+	parentNode, err := resolve(parentNode, nodes)
+	if err != nil {
+		return nil, err
+	}
+	r := &removal{parent: parentNode.Id, index: -1}
+	children := make([]*Node, 0)
+	for i, child := range parentNode.Children {
+		if child.Id == target {
+			r.node = child
+			r.index = i
+		} else {
+			children = append(children, child)
+		}
+	}
+	if r.node == nil {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", target))
 	}
-	return n, nil
+	parentNode.Children = children
+	x.remove(r.node)
+	r.promotions = promoteReferenced(r.node, x)
+	return r, nil
+}
+
+// promoteReferenced replaces the first reference to each node of the removed subtree with the node itself, indexing the
+// promoted nodes. The references are found through the index, and the promoted nodes may hold references to other nodes
+// of the removed subtree, which are promoted in turn
+func promoteReferenced(removed *Node, x *index) []promotion {
+	var promotions []promotion
+	parents := make(map[string]*Node)
+	for _, node := range removed.Traverse() {
+		for _, child := range node.Children {
+			parents[child.Id] = node
+		}
+	}
+	removedNodes := nodesById(removed)
+	for promoted := true; promoted; {
+		promoted = false
+		// the references are visited in Depth-First Search order, so that the first one takes the place of its target
+		var references []*Node
+		positions := make(map[*Node][]int)
+		for id := range removedNodes {
+			for _, reference := range x.references[id] {
+				references = append(references, reference)
+				positions[reference] = x.position(reference)
+			}
+		}
+		sort.Slice(references, func(a, b int) bool {
+			return slices.Compare(positions[references[a]], positions[references[b]]) < 0
+		})
+		for _, child := range references {
+			target, found := removedNodes[child.Ref]
+			if !found {
+				continue
+			}
+			node := x.parents[child.Id]
+			i := childIndex(node, child.Id)
+			p := promotion{parent: node.Id, index: i, reference: child, node: target.Id, originIndex: -1}
+			// detach the target from its parent in the removed subtree
+			if origin, found := parents[target.Id]; found {
+				siblings := make([]*Node, 0)
+				for j, sibling := range origin.Children {
+					if sibling != target {
+						siblings = append(siblings, sibling)
+					} else {
+						p.origin = origin.Id
+						p.originIndex = j
+					}
+				}
+				origin.Children = siblings
+			}
+			node.Children[i] = target
+			x.remove(child)
+			x.add(node, target)
+			for id := range nodesById(target) {
+				delete(removedNodes, id)
+			}
+			promotions = append(promotions, p)
+			promoted = true
+		}
+	}
+	return promotions
 }
@@ -54,3 +54,37 @@ func TestNode_RemoveNode_FailsParentNotFound(t *testing.T) {
 		t.Errorf("The error message does not match. Expected \"the parent node with ID \"Z\" was not found\", got %s", err)
 	}
 }
+
+func TestNode_RemoveNode_PromotesReferencedNode(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.LinkNode("id_B", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	root, err = root.RemoveNode("id_D", "id_G")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(b.Children) != 1 || b.Children[0].Id != "id_G" || len(b.Children[0].Children) != 2 {
+		t.Errorf("The node G did not replace its reference: %v", b.Children)
+	}
+	d, err := root.FindNode("id_D")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(d.Children) != 1 {
+		t.Errorf("The node G is still a child of node D")
+	}
+}
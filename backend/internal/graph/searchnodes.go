@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	exactMatch     = 100
+	prefixMatch    = 80
+	wordMatch      = 60
+	substringMatch = 40
+	allWordsMatch  = 20
+)
+
+// latinLetters maps the ligatures, the accented letters and the consonantal forms of i and u to the letters they are
+// normalized to
+var latinLetters = func() map[rune]string {
+	letters := map[rune]string{'æ': "ae", 'œ': "oe", 'j': "i", 'v': "u"}
+	for base, accented := range map[string]string{
+		"a": "àáâãäåāăą",
+		"c": "çćč",
+		"e": "èéêëēĕėęě",
+		"i": "ìíîïīĭįı",
+		"n": "ñń",
+		"o": "òóôõöøōŏő",
+		"u": "ùúûüūŭůűų",
+		"y": "ýÿȳ",
+	} {
+		for _, r := range accented {
+			letters[r] = base
+		}
+	}
+	return letters
+}()
+
+// Hit is a node matching a search, with the field that matched best, i.e., NameField or a property key, and the path of
+// its ancestors from the root
+type Hit struct {
+	PathStep
+	Field     string     `json:"field"`
+	Value     string     `json:"value"`
+	Score     int        `json:"score"`
+	Ancestors []PathStep `json:"ancestors"`
+}
+
+// Search returns the nodes whose name or property values match the query, best matches first. Matching ignores case,
+// diacritics and the Latin spelling variants u/v, i/j, ae/æ and oe/œ. An exact match ranks above a prefix, a word
+// prefix, a substring and a match of every word of the query, and a property ranks below a name matching as well.
+// References are skipped, since they match like the nodes they reference
+func (n *Node) Search(query string) ([]Hit, error) {
+	q := normalizeLatin(query)
+	if q == "" {
+		return nil, errors.NewIllegalArgumentError("the query cannot be empty")
+	}
+	hits := make([]Hit, 0)
+	walkPaths(n, func(node *Node, path []PathStep) {
+		if node.IsReference() {
+			return
+		}
+		hit := Hit{PathStep: path[len(path)-1], Field: NameField, Value: node.Name, Score: matchScore(q, node.Name)}
+		for _, key := range sortedKeys(node.Properties) {
+			// a property ranks below a name matching as well
+			if score := matchScore(q, node.Properties[key]) - 1; score > hit.Score {
+				hit.Field, hit.Value, hit.Score = key, node.Properties[key], score
+			}
+		}
+		if hit.Score > 0 {
+			hit.Ancestors = append([]PathStep{}, path[:len(path)-1]...)
+			hits = append(hits, hit)
+		}
+	})
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return utf8.RuneCountInString(hits[i].Value) < utf8.RuneCountInString(hits[j].Value)
+	})
+	return hits, nil
+}
+
+// normalizeLatin lowercases a string, strips its diacritics, spells out the ligatures æ and œ, replaces j with i and v
+// with u, and collapses its whitespace
+func normalizeLatin(s string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if letter, found := latinLetters[r]; found {
+			builder.WriteString(letter)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// matchScore returns how well a normalized query matches a value, 0 meaning that it does not match
+func matchScore(query, value string) int {
+	v := normalizeLatin(value)
+	switch {
+	case v == "":
+		return 0
+	case v == query:
+		return exactMatch
+	case strings.HasPrefix(v, query):
+		return prefixMatch
+	case strings.Contains(" "+v, " "+query):
+		return wordMatch
+	case strings.Contains(v, query):
+		return substringMatch
+	}
+	words := strings.Fields(v)
+	for _, q := range strings.Fields(query) {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, q) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	return allWordsMatch
+}
@@ -0,0 +1,104 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"reflect"
+	"testing"
+)
+
+func TestNode_Search_Ranking(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	names := map[string]string{
+		"id_B": "ens per se vel in se",
+		"id_C": "Ens in se",
+		"id_F": "ēns īn sē",
+		"id_G": "non ens",
+		"id_E": "essentia",
+	}
+	for id, name := range names {
+		node, err := root.FindNode(id)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		node.Name = name
+	}
+	i, err := root.FindNode("id_I")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	i.SetProperty("source", "De ente et essentia, ens in se")
+	_, err = root.LinkNode("id_E", "id_C")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+
+	hits, err := root.Search("ENS  IN SE")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	var actual []string
+	for _, hit := range hits {
+		actual = append(actual, hit.Id+" "+hit.Field+" "+hit.Number)
+	}
+	expected := []string{
+		"id_C name 1.2",
+		"id_F name 1.3.1",
+		"id_I source 1.3.2.2",
+		"id_B name 1.1",
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Unexpected hits %v", actual)
+	}
+	expectedAncestors := []graph.PathStep{
+		{Id: "0", Name: "ens", Type: "lexeme", Number: "1"},
+		{Id: "id_D", Name: "D", Type: "opposition", Number: "1.3"},
+		{Id: "id_G", Name: "non ens", Type: "lexeme", Number: "1.3.2"},
+	}
+	if !reflect.DeepEqual(expectedAncestors, hits[2].Ancestors) {
+		t.Errorf("Unexpected ancestors %v", hits[2].Ancestors)
+	}
+}
+
+func TestNode_Search_Orthography(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b, err := root.FindNode("id_B")
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	b.Name = "Cæsar, iustitia et uirtus"
+	for _, query := range []string{"caesar", "CÆSAR", "justitia", "virtus", "jus vir", "ÆSAR"} {
+		hits, err := root.Search(query)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if len(hits) != 1 || hits[0].Id != "id_B" {
+			t.Errorf("Unexpected hits for %q: %v", query, hits)
+		}
+	}
+}
+
+func TestNode_Search_Failure(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.Search("  ")
+	if err == nil || err.Error() != "the query cannot be empty" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package graph
+
+import "backend/internal/graph/errors"
+
+// SubtreeOptions are the options of Subtree
+type SubtreeOptions struct {
+	// Depth omits the nodes deeper than the given depth, where the returned node has depth 0. 0 means no limit
+	Depth int
+	// ChildrenOffset skips the given number of children of the returned node
+	ChildrenOffset int
+	// ChildrenLimit returns at most the given number of children of each node. 0 means no limit
+	ChildrenLimit int
+}
+
+// SubtreeNode is a node of a subtree. ChildCount is the number of children of the node in the graph, and HasMore is
+// true if some of them were left out, because of the depth or the page of children
+type SubtreeNode struct {
+	Id         string            `json:"id"`
+	Name       string            `json:"name"`
+	Type       NodeType          `json:"type"`
+	Color      string            `json:"color"`
+	Ref        string            `json:"ref,omitempty"`
+	Properties map[string]string `json:"properties"`
+	Children   []*SubtreeNode    `json:"children"`
+	ChildCount int               `json:"childCount"`
+	HasMore    bool              `json:"hasMore"`
+}
+
+// Subtree returns the node with the given ID and its descendants down to the given depth. The children of the returned
+// node start at the given offset, and each node keeps at most the given number of children, so that a client can fetch
+// a large graph one page at a time
+func (n *Node) Subtree(id string, options SubtreeOptions) (*SubtreeNode, error) {
+	return subtree(newIndex(n), id, options)
+}
+
+// Subtree returns the subtree of the node with the given ID like Node.Subtree, found through the index while holding
+// the read lock. The subtree shares nothing with the graph, which may change once the lock is released
+func (g *Graph) Subtree(id string, options SubtreeOptions) (*SubtreeNode, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return subtree(g.index, id, options)
+}
+
+// subtree returns the subtree of the node with the given ID in the graph whose index is given
+func subtree(x *index, id string, options SubtreeOptions) (*SubtreeNode, error) {
+	if options.Depth < 0 || options.ChildrenOffset < 0 || options.ChildrenLimit < 0 {
+		return nil, errors.NewIllegalArgumentError("the depth, the offset and the limit cannot be negative")
+	}
+	node, err := x.findNode(id)
+	if err != nil {
+		return nil, err
+	}
+	var visit func(node *Node, depth, offset int) *SubtreeNode
+	visit = func(node *Node, depth, offset int) *SubtreeNode {
+		subtree := &SubtreeNode{
+			Id:         node.Id,
+			Name:       node.Name,
+			Type:       node.Type,
+			Color:      node.Color,
+			Ref:        node.Ref,
+			Properties: make(map[string]string, len(node.Properties)),
+			Children:   make([]*SubtreeNode, 0),
+			ChildCount: len(node.Children),
+		}
+		for key, value := range node.Properties {
+			subtree.Properties[key] = value
+		}
+		if options.Depth > 0 && depth >= options.Depth {
+			subtree.HasMore = subtree.ChildCount > 0
+			return subtree
+		}
+		end := len(node.Children)
+		if options.ChildrenLimit > 0 {
+			end = min(end, offset+options.ChildrenLimit)
+		}
+		for i := offset; i < end; i++ {
+			subtree.Children = append(subtree.Children, visit(node.Children[i], depth+1, 0))
+		}
+		subtree.HasMore = len(subtree.Children) < subtree.ChildCount
+		return subtree
+	}
+	return visit(node, 0, min(options.ChildrenOffset, len(node.Children))), nil
+}
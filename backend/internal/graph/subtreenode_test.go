@@ -0,0 +1,89 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"testing"
+)
+
+func TestNode_Subtree_Depth(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	subtree, err := root.Subtree("0", graph.SubtreeOptions{Depth: 1})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if subtree.Id != "0" || subtree.ChildCount != 4 || len(subtree.Children) != 4 || subtree.HasMore {
+		t.Errorf("Unexpected root %+v", subtree)
+		return
+	}
+	d := subtree.Children[2]
+	if d.Id != "id_D" || d.ChildCount != 2 || len(d.Children) != 0 || !d.HasMore {
+		t.Errorf("Unexpected truncated node %+v", d)
+	}
+	b := subtree.Children[0]
+	if b.ChildCount != 0 || b.HasMore {
+		t.Errorf("Unexpected leaf %+v", b)
+	}
+
+	subtree, err = root.Subtree("id_D", graph.SubtreeOptions{})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(subtree.Children) != 2 || len(subtree.Children[1].Children) != 2 || subtree.Children[1].HasMore {
+		t.Errorf("Unexpected subtree %+v", subtree)
+	}
+}
+
+func TestNode_Subtree_Pagination(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	subtree, err := root.Subtree("0", graph.SubtreeOptions{ChildrenOffset: 1, ChildrenLimit: 2})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(subtree.Children) != 2 || subtree.Children[0].Id != "id_C" || subtree.Children[1].Id != "id_D" ||
+		!subtree.HasMore || subtree.ChildCount != 4 {
+		t.Errorf("Unexpected page %+v", subtree)
+		return
+	}
+	// the offset only applies to the children of the returned node
+	d := subtree.Children[1]
+	if len(d.Children) != 2 || d.Children[0].Id != "id_F" || d.HasMore {
+		t.Errorf("Unexpected children %+v", d)
+	}
+
+	subtree, err = root.Subtree("0", graph.SubtreeOptions{ChildrenOffset: 10})
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(subtree.Children) != 0 || !subtree.HasMore {
+		t.Errorf("Unexpected page %+v", subtree)
+	}
+}
+
+func TestNode_Subtree_Failure(t *testing.T) {
+	root, _, err := provisionNodes()
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	_, err = root.Subtree("0", graph.SubtreeOptions{ChildrenLimit: -1})
+	if _, ok := err.(*errors.IllegalArgumentError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+	_, err = root.Subtree("id_Z", graph.SubtreeOptions{})
+	if _, ok := err.(*errors.NodeNotFoundError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package graph
+
+// tidyNode is a node of the tidy tree being laid out by the Reingold-Tilford algorithm, in the linear-time version of
+// Buchheim, Jünger and Leipert
+type tidyNode struct {
+	node     *Node
+	parent   *tidyNode
+	children []*tidyNode
+	number   int
+	depth    int
+	size     float64
+	x        float64
+	prelim   float64
+	mod      float64
+	shift    float64
+	change   float64
+	thread   *tidyNode
+	ancestor *tidyNode
+}
+
+// tidyTree lays out the graph as a tidy tree and returns its nodes in Depth-First Search order. x is the position of
+// each node along the breadth of the tree, starting at 0, where adjacent nodes are separated by half their sizes plus
+// the given gap. References are leaves
+func tidyTree(root *Node, size func(node *Node) float64, gap float64) []*tidyNode {
+	var nodes []*tidyNode
+	var build func(node *Node, parent *tidyNode, number, depth int) *tidyNode
+	build = func(node *Node, parent *tidyNode, number, depth int) *tidyNode {
+		t := &tidyNode{node: node, parent: parent, number: number, depth: depth, size: size(node)}
+		t.ancestor = t
+		nodes = append(nodes, t)
+		for i, child := range node.Children {
+			t.children = append(t.children, build(child, t, i+1, depth+1))
+		}
+		return t
+	}
+	tree := build(root, nil, 1, 0)
+
+	l := &tidyLayout{gap: gap}
+	l.firstWalk(tree)
+	l.secondWalk(tree, -tree.prelim)
+	left := 0.0
+	for _, t := range nodes {
+		if t.x-t.size/2 < left {
+			left = t.x - t.size/2
+		}
+	}
+	for _, t := range nodes {
+		t.x -= left
+	}
+	return nodes
+}
+
+// tidyLayout holds the parameters of the layout
+type tidyLayout struct {
+	gap float64
+}
+
+// distance returns the minimal distance between the centers of two adjacent nodes
+func (l *tidyLayout) distance(left, right *tidyNode) float64 {
+	return (left.size+right.size)/2 + l.gap
+}
+
+// firstWalk computes the preliminary position of each node bottom up
+func (l *tidyLayout) firstWalk(v *tidyNode) {
+	w := v.leftSibling()
+	if len(v.children) == 0 {
+		if w != nil {
+			v.prelim = w.prelim + l.distance(w, v)
+		}
+		return
+	}
+	defaultAncestor := v.children[0]
+	for _, child := range v.children {
+		l.firstWalk(child)
+		defaultAncestor = l.apportion(child, defaultAncestor)
+	}
+	v.executeShifts()
+	midpoint := (v.children[0].prelim + v.children[len(v.children)-1].prelim) / 2
+	if w != nil {
+		v.prelim = w.prelim + l.distance(w, v)
+		v.mod = v.prelim - midpoint
+	} else {
+		v.prelim = midpoint
+	}
+}
+
+// apportion moves the subtree of v away from the subtrees of its left siblings so that their contours do not overlap
+func (l *tidyLayout) apportion(v, defaultAncestor *tidyNode) *tidyNode {
+	w := v.leftSibling()
+	if w == nil {
+		return defaultAncestor
+	}
+	vir, vor := v, v
+	vil, vol := w, v.parent.children[0]
+	sir, sor := v.mod, v.mod
+	sil, sol := vil.mod, vol.mod
+	for vil.nextRight() != nil && vir.nextLeft() != nil {
+		vil, vir = vil.nextRight(), vir.nextLeft()
+		vol, vor = vol.nextLeft(), vor.nextRight()
+		vor.ancestor = v
+		shift := (vil.prelim + sil) - (vir.prelim + sir) + l.distance(vil, vir)
+		if shift > 0 {
+			moveSubtree(ancestor(vil, v, defaultAncestor), v, shift)
+			sir += shift
+			sor += shift
+		}
+		sil += vil.mod
+		sir += vir.mod
+		sol += vol.mod
+		sor += vor.mod
+	}
+	if vil.nextRight() != nil && vor.nextRight() == nil {
+		vor.thread = vil.nextRight()
+		vor.mod += sil - sor
+	}
+	if vir.nextLeft() != nil && vol.nextLeft() == nil {
+		vol.thread = vir.nextLeft()
+		vol.mod += sir - sol
+		defaultAncestor = v
+	}
+	return defaultAncestor
+}
+
+// secondWalk computes the final position of each node top down
+func (l *tidyLayout) secondWalk(v *tidyNode, m float64) {
+	v.x = v.prelim + m
+	for _, child := range v.children {
+		l.secondWalk(child, m+v.mod)
+	}
+}
+
+// moveSubtree shifts the subtree of wr and spreads the shift among the subtrees between wl and wr
+func moveSubtree(wl, wr *tidyNode, shift float64) {
+	subtrees := float64(wr.number - wl.number)
+	wr.change -= shift / subtrees
+	wr.shift += shift
+	wl.change += shift / subtrees
+	wr.prelim += shift
+	wr.mod += shift
+}
+
+// executeShifts applies the shifts of the children of v
+func (v *tidyNode) executeShifts() {
+	shift, change := 0.0, 0.0
+	for i := len(v.children) - 1; i >= 0; i-- {
+		w := v.children[i]
+		w.prelim += shift
+		w.mod += shift
+		change += w.change
+		shift += w.shift + change
+	}
+}
+
+// leftSibling returns the sibling on the left of v, if any
+func (v *tidyNode) leftSibling() *tidyNode {
+	if v.parent == nil || v.number == 1 {
+		return nil
+	}
+	return v.parent.children[v.number-2]
+}
+
+// nextLeft returns the next node of the left contour
+func (v *tidyNode) nextLeft() *tidyNode {
+	if len(v.children) > 0 {
+		return v.children[0]
+	}
+	return v.thread
+}
+
+// nextRight returns the next node of the right contour
+func (v *tidyNode) nextRight() *tidyNode {
+	if len(v.children) > 0 {
+		return v.children[len(v.children)-1]
+	}
+	return v.thread
+}
+
+// ancestor returns the ancestor of vil which is a sibling of v, or the default ancestor
+func ancestor(vil, v, defaultAncestor *tidyNode) *tidyNode {
+	if vil.ancestor.parent == v.parent {
+		return vil.ancestor
+	}
+	return defaultAncestor
+}
@@ -13,19 +13,24 @@ func (n *Node) Traverse() []*Node {
 func traverse(node *Node, traversed []*Node) []*Node {
 	traversed = append(traversed, node)
 	for _, child := range node.Children {
-		if child.Id == "" {
-			child.Id = uuid.New().String()
-		}
-		if child.Type == "" {
-			child.Type = lexeme
-		}
-		if child.Properties == nil {
-			child.Properties = make(map[string]string)
-		}
-		if child.Children == nil {
-			child.Children = make([]*Node, 0)
-		}
+		fillDefaults(child)
 		traversed = traverse(child, traversed)
 	}
 	return traversed
 }
+
+// fillDefaults fills in the ID, the type, the properties and the children of a node which lacks them
+func fillDefaults(node *Node) {
+	if node.Id == "" {
+		node.Id = uuid.New().String()
+	}
+	if node.Type == "" {
+		node.Type = lexeme
+	}
+	if node.Properties == nil {
+		node.Properties = make(map[string]string)
+	}
+	if node.Children == nil {
+		node.Children = make([]*Node, 0)
+	}
+}
@@ -5,49 +5,77 @@ import (
 	"fmt"
 )
 
-// UpdateNode updates a graph's node
+// UpdateNode updates a graph's node. Updating a reference updates the referenced node and thus all its references
 func (n *Node) UpdateNode(parent string, targetNode *Node) (*Node, error) {
+	return n.updateNode(newIndex(n), parent, targetNode)
+}
+
+// updateNode updates a node of the graph whose index is given
+func (n *Node) updateNode(x *index, parent string, targetNode *Node) (*Node, error) {
 	if targetNode == nil {
 		return nil, errors.NewIllegalArgumentError("targetNode cannot be nil")
 	}
 
-	parentFound := false
-	targetFound := false
-	nodes := n.Traverse()
-	for _, parentNode := range nodes {
-		if parentNode.Id == parent {
-			parentFound = true
-			for _, child := range parentNode.Children {
-				if child.Id == targetNode.Id {
-					targetFound = true
-					child.Name = targetNode.Name
-					child.Type = targetNode.Type
-					if targetNode.Color == "" {
-						child.Color = DefaultColor
-					} else {
-						child.Color = targetNode.Color
-					}
-					child.Properties = targetNode.Properties
-					if targetNode.Children != nil && len(targetNode.Children) == 1 {
-						// there should be only one child
-						newChild := targetNode.Children[0]
-						// the id must be unique
-						for _, node := range nodes {
-							if node.Id == newChild.Id {
-								return nil, errors.NewDuplicatedNodeError(fmt.Sprintf("duplicated ID %q", newChild.Id))
-							}
-						}
-						child.Children = append(child.Children, newChild)
-					}
-				}
-			}
-		}
-	}
-	if !parentFound {
+	nodes := x.nodes
+	parentNode, found := nodes[parent]
+	if !found {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the parent node with ID %q was not found", parent))
 	}
-	if !targetFound {
+	parentNode, err := resolve(parentNode, nodes)
+	if err != nil {
+		return nil, err
+	}
+	var child *Node
+	for _, c := range parentNode.Children {
+		if c.Id == targetNode.Id {
+			child = c
+		}
+	}
+	if child == nil {
 		return nil, errors.NewNodeNotFoundError(fmt.Sprintf("the target node with ID %q was not found", targetNode.Id))
 	}
+
+	node, err := resolve(child, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if node == child && targetNode.Type == reference {
+		return nil, errors.NewIllegalArgumentError(fmt.Sprintf("the node %q cannot be turned into a reference", child.Id))
+	}
+	if targetNode.Children != nil && len(targetNode.Children) == 1 {
+		// there should be only one child
+		newChild := targetNode.Children[0]
+		// the id must be unique
+		if _, found := nodes[newChild.Id]; found {
+			return nil, errors.NewDuplicatedNodeError(fmt.Sprintf("duplicated ID %q", newChild.Id))
+		}
+		node.Children = append(node.Children, newChild)
+		x.add(node, newChild)
+		// the references of the new child must not create any cycle
+		err = checkReferences(n, newChild, nodes)
+		if err != nil {
+			node.Children = node.Children[:len(node.Children)-1]
+			x.remove(newChild)
+			return nil, err
+		}
+		for _, node := range newChild.Traverse() {
+			x.syncReference(node)
+		}
+	}
+	if node == child {
+		node.Type = targetNode.Type
+	} else if targetNode.Type != reference && targetNode.Type != "" {
+		node.Type = targetNode.Type
+	}
+	node.Name = targetNode.Name
+	if targetNode.Color == "" {
+		node.Color = DefaultColor
+	} else {
+		node.Color = targetNode.Color
+	}
+	node.Properties = targetNode.Properties
+	fillDefaults(node)
+	// only the references to the updated node are out of sync
+	x.syncReferencesTo(node)
 	return n, nil
 }
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"backend/internal/graph/errors"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// colorPattern matches the hex colors #rgb and #rrggbb
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate checks a graph's JSON representation before it is parsed and returns a validation error listing every
+// issue with its JSON path: duplicated IDs, unknown types, invalid colors, empty names, dangling references, references
+// creating a cycle and a root whose ID is not "0". Missing IDs and types are not issues, since Parse fills them in
+func Validate(bytes []byte) error {
+	root := &Node{}
+	err := json.Unmarshal(bytes, root)
+	if err != nil {
+		return errors.NewParsingError(fmt.Sprintf("failed to parse the node [%s]", err))
+	}
+	v := &validator{nodes: make(map[string]*Node), paths: make(map[string]string), issues: make([]errors.Issue, 0)}
+	if root.Id != "0" {
+		v.issue("$.id", fmt.Sprintf("the root node must have the ID \"0\", got %q", root.Id))
+	}
+	v.visit(root, "$")
+	cyclic := make(map[*Node]bool)
+	for _, node := range cyclicReferences(root, v.nodes) {
+		cyclic[node] = true
+	}
+	for _, r := range v.references {
+		target, found := v.nodes[r.node.Ref]
+		if !found {
+			v.issue(r.path+".ref", fmt.Sprintf("the referenced node %q was not found", r.node.Ref))
+		} else if target.Type == reference {
+			v.issue(r.path+".ref", fmt.Sprintf("the referenced node %q is a reference", r.node.Ref))
+		} else if cyclic[r.node] {
+			v.issue(r.path+".ref", fmt.Sprintf("the reference to %q creates a cycle", r.node.Ref))
+		}
+	}
+	if len(v.issues) > 0 {
+		return errors.NewValidationError(fmt.Sprintf("the graph has %d issue(s)", len(v.issues)), v.issues)
+	}
+	return nil
+}
+
+// validator collects the issues of a graph
+type validator struct {
+	nodes      map[string]*Node
+	paths      map[string]string
+	references []validatedReference
+	issues     []errors.Issue
+}
+
+// validatedReference is a reference whose target is checked once all nodes have been visited
+type validatedReference struct {
+	node *Node
+	path string
+}
+
+// visit recursively checks the given node and its children
+func (v *validator) visit(node *Node, path string) {
+	if node.Id != "" {
+		if first, found := v.paths[node.Id]; found {
+			v.issue(path+".id", fmt.Sprintf("duplicated ID %q, already used at %s", node.Id, first))
+		} else {
+			v.nodes[node.Id] = node
+			v.paths[node.Id] = path
+		}
+	}
+	switch node.Type {
+	case "", division, lexeme, opposition:
+	case reference:
+		if node.Ref == "" {
+			v.issue(path+".ref", "a reference must have a referenced node")
+		} else {
+			v.references = append(v.references, validatedReference{node: node, path: path})
+		}
+	default:
+		v.issue(path+".type", fmt.Sprintf("unknown type %q", node.Type))
+	}
+	if node.Color != "" && !colorPattern.MatchString(node.Color) {
+		v.issue(path+".color", fmt.Sprintf("invalid color %q", node.Color))
+	}
+	// the names of references mirror the names of the referenced nodes, while a name made of spaces hides a node
+	if node.Type != reference && node.Name == "" {
+		v.issue(path+".name", "the name cannot be empty")
+	}
+	for i, child := range node.Children {
+		childPath := fmt.Sprintf("%s.children[%d]", path, i)
+		if child == nil {
+			v.issue(childPath, "the node cannot be null")
+			continue
+		}
+		v.visit(child, childPath)
+	}
+}
+
+// issue records an issue
+func (v *validator) issue(path, message string) {
+	v.issues = append(v.issues, errors.Issue{Path: path, Message: message})
+}
@@ -0,0 +1,72 @@
+package graph_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValidate_Success(t *testing.T) {
+	err := graph.Validate(testGraphData)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+}
+
+func TestValidate_Fails(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected []errors.Issue
+	}{
+		{
+			`{"id":"1","name":"ens","children":[
+				{"id":"id_B","name":"","type":"lexeme","color":"#ff0000","children":[]},
+				{"id":"id_B","name":"C","type":"genus","color":"ff0000","children":[]},
+				{"id":"id_D","name":" ","type":"reference","ref":"id_Z","children":[null]}]}`,
+			[]errors.Issue{
+				{Path: "$.id", Message: "the root node must have the ID \"0\", got \"1\""},
+				{Path: "$.children[0].name", Message: "the name cannot be empty"},
+				{Path: "$.children[1].id", Message: "duplicated ID \"id_B\", already used at $.children[0]"},
+				{Path: "$.children[1].type", Message: "unknown type \"genus\""},
+				{Path: "$.children[1].color", Message: "invalid color \"ff0000\""},
+				{Path: "$.children[2].children[0]", Message: "the node cannot be null"},
+				{Path: "$.children[2].ref", Message: "the referenced node \"id_Z\" was not found"},
+			},
+		},
+		{
+			`{"id":"0","name":"ens","children":[
+				{"id":"id_B","name":"B","children":[{"type":"reference","ref":"0","children":[]}]}]}`,
+			[]errors.Issue{
+				{Path: "$.children[0].children[0].ref", Message: "the reference to \"0\" creates a cycle"},
+			},
+		},
+		{
+			`{"id":"0","name":"ens","children":[
+				{"id":"id_B","name":"B","children":[{"id":"id_D","type":"reference","ref":"id_C","children":[]}]},
+				{"id":"id_C","name":"C","children":[{"id":"id_E","type":"reference","ref":"id_B","children":[]}]}]}`,
+			[]errors.Issue{
+				{Path: "$.children[1].children[0].ref", Message: "the reference to \"id_B\" creates a cycle"},
+			},
+		},
+	} {
+		err := graph.Validate([]byte(test.data))
+		if err == nil {
+			t.Errorf("Validate did not return an error for %s", test.data)
+			continue
+		}
+		validationError, ok := err.(*errors.ValidationError)
+		if !ok {
+			t.Errorf("Unexpected error %s", err)
+			continue
+		}
+		if !reflect.DeepEqual(validationError.Issues(), test.expected) {
+			t.Errorf("The issues do not match. Expected %v, got %v", test.expected, validationError.Issues())
+		}
+		message := fmt.Sprintf("the graph has %d issue(s)", len(test.expected))
+		if err.Error() != message {
+			t.Errorf("The error message does not match. Expected %q, got %s", message, err)
+		}
+	}
+}
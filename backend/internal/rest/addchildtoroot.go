@@ -17,13 +17,10 @@ func (server *HttpServer) addChildToRootNode(context *gin.Context) {
 		handleFailedRequest(context, err, msg)
 		return
 	}
-	root, err := server.g.Root.AddNode("0", node)
+	err = server.g.Do(graph.NewAddOperation("0", node))
 	if err != nil {
 		msg := fmt.Sprintf("Failed to add the node to the graph's root [%s]", err)
 		log.Error(msg)
 		handleFailedRequest(context, err, msg)
-		return
 	}
-	server.g.Root = root
-	server.g.Save()
 }
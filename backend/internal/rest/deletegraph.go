@@ -1,12 +1,20 @@
 package rest
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 	"net/http"
 )
 
 // deleteGraph clear, i.e., resets, the graph
 func (server *HttpServer) deleteGraph(context *gin.Context) {
-	server.g.Clear()
+	err := server.g.Clear()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to clear the graph [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
 	context.Writer.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// diffGraph returns the changes turning the current graph into the uploaded one
+func (server *HttpServer) diffGraph(context *gin.Context) {
+	bytes, err := readFile(context, "file")
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read the uploaded graph [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	uploaded, err := parseGraph(bytes)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to parse the uploaded graph [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	var changes graph.Changes
+	_ = server.g.View(func(root *graph.Node) error {
+		changes = graph.Diff(root, uploaded)
+		return nil
+	})
+	writeChanges(context, changes)
+}
+
+// writeChanges writes the changes as JSON or, with format=text, as a human-readable list
+func writeChanges(context *gin.Context, changes graph.Changes) {
+	format := context.DefaultQuery("format", "json")
+	switch format {
+	case "json":
+		context.JSON(http.StatusOK, changes)
+	case "text":
+		context.Header(contentType, textPlain)
+		context.String(http.StatusOK, changes.Stringify())
+	default:
+		msg := fmt.Sprintf("Unsupported diff format %q", format)
+		log.Error(msg)
+		handleFailedRequest(context, errors.NewIllegalArgumentError(msg), msg)
+	}
+}
@@ -0,0 +1,44 @@
+package rest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_DiffGraph(t *testing.T) {
+	router := provisionRouter(t)
+	amended := strings.Replace(testGraph, `"name":"C"`, `"name":"C2"`, 1)
+	response := upload(router, "/apis/graph/diff?format=text", amended)
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to diff the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	expected := "~ 1.2 C2: renamed from \"C\" to \"C2\"\n"
+	if response.Body.String() != expected {
+		t.Errorf("The diff does not match. Expected %q, got %q", expected, response.Body.String())
+	}
+
+	response = upload(router, "/apis/graph/diff?format=xml", amended)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", response.Code)
+	}
+}
+
+func TestHttpServer_DiffRevision(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodDelete, "/apis/nodes/0/id_B", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to delete the node: %d %s", response.Code, response.Body)
+		return
+	}
+	response = serve(router, http.MethodGet, "/apis/history/1/diff?format=text", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to diff the revision: %d %s", response.Code, response.Body)
+		return
+	}
+	expected := "- 1.1 B\n"
+	if response.Body.String() != expected {
+		t.Errorf("The diff does not match. Expected %q, got %q", expected, response.Body.String())
+	}
+}
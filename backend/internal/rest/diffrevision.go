@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// diffRevision returns the changes turning the graph at the given revision into the current graph
+func (server *HttpServer) diffRevision(context *gin.Context) {
+	revision, err := revisionParam(context)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the revision [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	old, err := server.g.Revision(revision)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the revision %d [%s]", revision, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	var changes graph.Changes
+	_ = server.g.View(func(root *graph.Node) error {
+		changes = graph.Diff(old, root)
+		return nil
+	})
+	writeChanges(context, changes)
+}
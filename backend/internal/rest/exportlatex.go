@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"github.com/gin-gonic/gin"
+)
+
+// exportNode returns the subtree of the given node as a forest LaTeX block
+func (server *HttpServer) exportNode(context *gin.Context) {
+	exporter, _ := graph.FindExporter("latex")
+	server.export(context, exporter, context.Param("node"))
+}
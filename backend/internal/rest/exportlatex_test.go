@@ -0,0 +1,33 @@
+package rest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_ExportLatex_Node(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/nodes/id_D/latex", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the node: %d %s", response.Code, response.Body)
+		return
+	}
+	expected := "\\begin{forest}\n  [{D}, fill=color00FF00, draw, double\n    [{F}, fill=color0000FF, draw, rounded corners]\n  ]\n\\end{forest}\n"
+	if !strings.HasSuffix(response.Body.String(), expected) {
+		t.Errorf("Unexpected LaTeX document %s", response.Body)
+	}
+}
+
+func TestHttpServer_ExportLatex_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	for path, code := range map[string]int{
+		"/apis/nodes/unknown/latex":      http.StatusNotFound,
+		"/apis/nodes/id_D/latex?depth=x": http.StatusBadRequest,
+	} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != code {
+			t.Errorf("Unexpected status code for %s: %d %s", path, response.Code, response.Body)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// findPath returns the path from the root to the given node, with the outline number of each node
+func (server *HttpServer) findPath(context *gin.Context) {
+	node := context.Param("node")
+	path, err := server.g.FindPath(node)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to find the path to node %q [%s]", node, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.JSON(http.StatusOK, path)
+}
@@ -0,0 +1,35 @@
+package rest_test
+
+import (
+	"backend/internal/graph"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_FindPath_Success(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/nodes/id_F/path", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to find the path: %d %s", response.Code, response.Body)
+		return
+	}
+	var path []graph.PathStep
+	err := json.Unmarshal(response.Body.Bytes(), &path)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(path) != 3 || path[0].Id != "0" || path[1].Id != "id_D" || path[1].Type != "opposition" ||
+		path[2].Name != "F" || path[2].Number != "1.3.1" {
+		t.Errorf("Unexpected path %s", response.Body)
+	}
+}
+
+func TestHttpServer_FindPath_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/nodes/id_Z/path", "")
+	if response.Code != http.StatusNotFound {
+		t.Errorf("Unexpected status code: %d %s", response.Code, response.Body)
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"backend/internal/graph"
 	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
@@ -11,7 +12,11 @@ import (
 // findTargets returns the nodes to which the given node can be moved
 func (server *HttpServer) findTargets(context *gin.Context) {
 	node := context.Param("node")
-	nodes, err := server.g.Root.FindTargetNodes(node)
+	var nodes []*graph.Node
+	err := server.g.View(func(root *graph.Node) (err error) {
+		nodes, err = root.FindTargetNodes(node)
+		return err
+	})
 	if err != nil {
 		msg := fmt.Sprintf("Failed to find the target nodes of node %q [%s]", node, err)
 		log.Error(msg)
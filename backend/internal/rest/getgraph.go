@@ -1,15 +1,40 @@
 package rest
 
 import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
 )
 
-// graph returns the graph
+// getGraph returns the graph, or the subtree of the node root=<id>, in the format named by format=<name> or else
+// negotiated from the Accept header, which defaults to JSON
 func (server *HttpServer) getGraph(context *gin.Context) {
-	json, err := server.g.Root.String()
+	exporter := graph.NegotiateExporter(context.GetHeader("Accept"))
+	if format := context.Query("format"); format != "" {
+		var err error
+		exporter, err = graph.FindExporter(format)
+		if err != nil {
+			msg := err.Error()
+			log.Error(msg)
+			handleFailedRequest(context, err, msg)
+			return
+		}
+	}
+	server.export(context, exporter, context.DefaultQuery("root", "0"))
+}
+
+// writeGraph returns the whole graph as JSON, e.g., after a mutation
+func (server *HttpServer) writeGraph(context *gin.Context) {
+	var json string
+	err := server.g.View(func(root *graph.Node) error {
+		var err error
+		json, err = root.String()
+		return err
+	})
 	if err != nil {
 		msg := fmt.Sprintf("Failed to generate the JSON string [%s]", err)
 		log.Error(msg)
@@ -19,3 +44,53 @@ func (server *HttpServer) getGraph(context *gin.Context) {
 	context.Header(contentType, applicationJson)
 	context.String(http.StatusOK, json)
 }
+
+// export returns the subtree of the given node as a file in the format of the given exporter. The options are read
+// from the request's query: depth=<n> truncates the tree, omitHidden=true omits the hidden nodes and base=<iri> sets
+// the base IRI of the SKOS resources
+func (server *HttpServer) export(context *gin.Context, exporter graph.Exporter, id string) {
+	options, err := exportOptions(context)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to export the node %q [%s]", id, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	var bytes []byte
+	err = server.g.ViewNode(id, func(root, node *graph.Node) error {
+		options.Root = root
+		bytes, err = exporter.Export(node, options)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to export the node %q as %s [%s]", id, exporter.Format(), err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.Header(contentDisposition, fmt.Sprintf("attachment; filename=\"graph.%s\"", exporter.Extension()))
+	context.Data(http.StatusOK, exporter.ContentType(), bytes)
+}
+
+// exportOptions returns the export options of the request's query
+func exportOptions(context *gin.Context) (graph.ExportOptions, error) {
+	depth, err := countParam(context, "depth")
+	return graph.ExportOptions{
+		Base:       context.Query("base"),
+		MaxDepth:   depth,
+		OmitHidden: context.Query("omitHidden") == "true",
+	}, err
+}
+
+// countParam returns the non-negative integer of the given parameter of the request's query, or 0 if there is none
+func countParam(context *gin.Context, name string) (int, error) {
+	param := context.Query(name)
+	if param == "" {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(param)
+	if err != nil || count < 0 {
+		return 0, errors.NewIllegalArgumentError(fmt.Sprintf("invalid %s %q", name, param))
+	}
+	return count, nil
+}
@@ -0,0 +1,121 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_GetGraph_Accept(t *testing.T) {
+	router := provisionRouter(t)
+	for accept, expected := range map[string]struct {
+		contentType, disposition, body string
+	}{
+		"":                               {"application/json", "attachment; filename=\"graph.json\"", "{\"id\":\"0\""},
+		"text/plain":                     {"text/plain", "attachment; filename=\"graph.txt\"", "1.3.1 F\n"},
+		"text/csv, */*;q=.1":             {"text/csv", "attachment; filename=\"graph.csv\"", "id_F,id_D,F,"},
+		"text/vnd.graphviz":              {"text/vnd.graphviz", "attachment; filename=\"graph.dot\"", "\"id_D\" -> \"id_F\";"},
+		"application/vnd.cytoscape+json": {"application/vnd.cytoscape+json", "attachment; filename=\"graph.cyjs\"", "\"elements\""},
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/apis/graph", nil)
+		request.Header.Set("Accept", accept)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		if response.Code != http.StatusOK {
+			t.Errorf("Failed to get the graph as %q: %d %s", accept, response.Code, response.Body)
+			continue
+		}
+		if response.Header().Get("Content-Type") != expected.contentType {
+			t.Errorf("Unexpected content type %q for %q", response.Header().Get("Content-Type"), accept)
+		}
+		if response.Header().Get("Content-Disposition") != expected.disposition {
+			t.Errorf("Unexpected content disposition %q for %q", response.Header().Get("Content-Disposition"), accept)
+		}
+		if !strings.Contains(response.Body.String(), expected.body) {
+			t.Errorf("Unexpected body for %q: %s", accept, response.Body)
+		}
+	}
+}
+
+func TestHttpServer_GetGraph_Format(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/graph?format=yaml&root=id_D", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to get the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	if response.Header().Get("Content-Type") != "text/yaml" || response.Body.String() != "F:\n" {
+		t.Errorf("Unexpected YAML document %q %s", response.Header().Get("Content-Type"), response.Body)
+	}
+	response = serve(router, http.MethodGet, "/apis/graph?format=unknown", "")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status code %d %s", response.Code, response.Body)
+	}
+}
+
+func TestHttpServer_ExportGraph(t *testing.T) {
+	router := provisionRouter(t)
+	// the reference leaves the subtree of B
+	response := serve(router, http.MethodPut, "/apis/nodes/id_B/id_F", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to link the node: %d %s", response.Code, response.Body)
+		return
+	}
+	for _, test := range []struct {
+		query, contentType, extension, expected string
+	}{
+		{"format=dot", "text/vnd.graphviz", "dot", "\"id_D\" [label=\"D\", shape=diamond, fillcolor=\"#00ff00\"];"},
+		{"format=ttl", "text/turtle", "ttl", "<urn:divisio-entis:id_F> a skos:Concept ;\n    skos:prefLabel \"F\"@la ;"},
+		{"format=jsonld", "application/ld+json", "jsonld", "\"@id\": \"urn:divisio-entis:id_F\""},
+		{"format=graphml", "application/graphml+xml", "graphml", "<edge id=\"e_id_F\" source=\"id_D\" target=\"id_F\">"},
+		{"format=cytoscape", "application/vnd.cytoscape+json", "cyjs", "\"source\": \"id_D\""},
+		{"format=graphml&root=id_B", "application/graphml+xml", "graphml", "<node id=\"id_F\">\n      <data key=\"name\">F</data>"},
+		{"format=cytoscape&root=id_B", "application/vnd.cytoscape+json", "cyjs", "\"id\": \"id_F\""},
+		{"format=mermaid&root=id_D&depth=1", "text/vnd.mermaid", "mmd", "flowchart TD\n  n0{{\"D\"}}\n  n1(\"F\")\n  n0 --> n1\n"},
+		{"format=latex&depth=1", "application/x-latex", "tex", "    [{D}, fill=color00FF00, draw, double]\n"},
+	} {
+		response := serve(router, http.MethodGet, "/apis/graph/export?"+test.query, "")
+		if response.Code != http.StatusOK {
+			t.Errorf("Failed to export the graph with %s: %d %s", test.query, response.Code, response.Body)
+			continue
+		}
+		if response.Header().Get("Content-Type") != test.contentType {
+			t.Errorf("Unexpected content type %q for %s", response.Header().Get("Content-Type"), test.query)
+		}
+		disposition := "attachment; filename=\"graph." + test.extension + "\""
+		if response.Header().Get("Content-Disposition") != disposition {
+			t.Errorf("Unexpected content disposition %q for %s", response.Header().Get("Content-Disposition"), test.query)
+		}
+		if !strings.Contains(response.Body.String(), test.expected) {
+			t.Errorf("Unexpected document for %s: %s", test.query, response.Body)
+		}
+	}
+}
+
+func TestHttpServer_ExportGraph_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	for path, code := range map[string]int{
+		"/apis/graph/export?format=mermaid&root=unknown":                   http.StatusNotFound,
+		"/apis/graph/export?format=mermaid&depth=-1":                       http.StatusBadRequest,
+		"/apis/graph/export?format=ttl&base=http://example.org/%3E%20.%0A": http.StatusBadRequest,
+	} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != code {
+			t.Errorf("Unexpected status code for %s: %d %s", path, response.Code, response.Body)
+		}
+	}
+}
+
+func TestHttpServer_WriteGraph(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodPost, "/apis/nodes/id_D/id_F/0?format=yaml&root=id_D&depth=1", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to move the node: %d %s", response.Code, response.Body)
+		return
+	}
+	if response.Header().Get("Content-Type") != "application/json" ||
+		response.Header().Get("Content-Disposition") != "" || !strings.HasPrefix(response.Body.String(), "{\"id\":\"0\"") {
+		t.Errorf("The graph was not returned as JSON: %v %s", response.Header(), response.Body)
+	}
+}
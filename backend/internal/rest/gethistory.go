@@ -0,0 +1,11 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// getHistory returns the recorded revisions of the graph
+func (server *HttpServer) getHistory(context *gin.Context) {
+	context.JSON(http.StatusOK, server.g.Revisions())
+}
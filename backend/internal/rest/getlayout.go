@@ -0,0 +1,26 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// getLayout returns the computed position of every node for the requested orientation
+func (server *HttpServer) getLayout(context *gin.Context) {
+	orientation := graph.Orientation(context.DefaultQuery("orientation", string(graph.TopDown)))
+	var layout *graph.Layout
+	err := server.g.View(func(root *graph.Node) (err error) {
+		layout, err = root.Layout(orientation)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to lay out the graph [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.JSON(http.StatusOK, layout)
+}
@@ -0,0 +1,33 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_GetLayout(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/graph/layout?orientation=left-right", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to lay out the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	var layout struct {
+		Orientation string           `json:"orientation"`
+		Nodes       []map[string]any `json:"nodes"`
+	}
+	err := json.Unmarshal(response.Body.Bytes(), &layout)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if layout.Orientation != "left-right" || len(layout.Nodes) != 5 {
+		t.Errorf("Unexpected layout %s", response.Body)
+	}
+
+	response = serve(router, http.MethodGet, "/apis/graph/layout?orientation=diagonal", "")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", response.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// getNode returns the given node and its descendants down to depth=<n>, with the page of its children starting at
+// childrenOffset=<n> and at most childrenLimit=<n> children per node
+func (server *HttpServer) getNode(context *gin.Context) {
+	node := context.Param("node")
+	var options graph.SubtreeOptions
+	var err error
+	options.Depth, err = countParam(context, "depth")
+	if err == nil {
+		options.ChildrenOffset, err = countParam(context, "childrenOffset")
+	}
+	if err == nil {
+		options.ChildrenLimit, err = countParam(context, "childrenLimit")
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the node %q [%s]", node, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	subtree, err := server.g.Subtree(node, options)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the node %q [%s]", node, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.JSON(http.StatusOK, subtree)
+}
@@ -0,0 +1,45 @@
+package rest_test
+
+import (
+	"backend/internal/graph"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_GetNode_Success(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/nodes/0?depth=1&childrenOffset=1&childrenLimit=1", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to get the node: %d %s", response.Code, response.Body)
+		return
+	}
+	var subtree graph.SubtreeNode
+	err := json.Unmarshal(response.Body.Bytes(), &subtree)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if subtree.ChildCount != 3 || !subtree.HasMore || len(subtree.Children) != 1 {
+		t.Errorf("Unexpected node %s", response.Body)
+		return
+	}
+	if c := subtree.Children[0]; c.Id != "id_C" || c.ChildCount != 0 || c.HasMore {
+		t.Errorf("Unexpected child %s", response.Body)
+	}
+}
+
+func TestHttpServer_GetNode_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	for path, code := range map[string]int{
+		"/apis/nodes/id_Z":                   http.StatusNotFound,
+		"/apis/nodes/0?depth=x":              http.StatusBadRequest,
+		"/apis/nodes/0?childrenLimit=-1":     http.StatusBadRequest,
+		"/apis/nodes/0?childrenOffset=first": http.StatusBadRequest,
+	} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != code {
+			t.Errorf("Unexpected status code for %s: %d %s", path, response.Code, response.Body)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+// getRevision returns the graph at the given revision
+func (server *HttpServer) getRevision(context *gin.Context) {
+	revision, err := revisionParam(context)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the revision [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	root, err := server.g.Revision(revision)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get the revision %d [%s]", revision, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	json, err := root.String()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to generate the JSON string [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.Header(contentType, applicationJson)
+	context.String(http.StatusOK, json)
+}
+
+// revisionParam returns the revision of the request's path
+func revisionParam(context *gin.Context) (int, error) {
+	param := context.Param("rev")
+	revision, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, errors.NewIllegalArgumentError(fmt.Sprintf("invalid revision %q", param))
+	}
+	return revision, nil
+}
@@ -0,0 +1,54 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_RestoreRevision(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodDelete, "/apis/graph", "")
+	if response.Code != http.StatusNoContent {
+		t.Errorf("Failed to delete the graph: %d %s", response.Code, response.Body)
+		return
+	}
+
+	response = serve(router, http.MethodGet, "/apis/history", "")
+	var revisions []map[string]any
+	err := json.Unmarshal(response.Body.Bytes(), &revisions)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(revisions) != 2 || revisions[1]["operation"] != "clear the graph" {
+		t.Errorf("Unexpected revisions %v", revisions)
+		return
+	}
+
+	response = serve(router, http.MethodPost, "/apis/history/1/restore", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to restore the revision: %d %s", response.Code, response.Body)
+		return
+	}
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if actual != 5 {
+		t.Errorf("Expected 5 nodes, got %d", actual)
+	}
+}
+
+func TestHttpServer_GetRevision_Fails(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/history/abc", "")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", response.Code)
+	}
+	response = serve(router, http.MethodGet, "/apis/history/42", "")
+	if response.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", response.Code)
+	}
+}
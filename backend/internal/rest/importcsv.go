@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// importCsv imports an uploaded CSV file. With parent=<id>, the nodes without a parent are added as children of the
+// given node; otherwise the file must hold the whole graph, which replaces the current one once validated
+func (server *HttpServer) importCsv(context *gin.Context) {
+	if _, found := context.GetQuery("parent"); found {
+		server.importNodes(context, "a CSV file", graph.ImportCsv, addNodes)
+		return
+	}
+	bytes, err := readFile(context, "file")
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	nodes, err := graph.ImportCsv(bytes)
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	if len(nodes) != 1 {
+		msg := fmt.Sprintf("The CSV file must have exactly one row without a parent, got %d", len(nodes))
+		log.Error(msg)
+		handleFailedRequest(context, errors.NewIllegalArgumentError(msg), msg)
+		return
+	}
+	json, err := nodes[0].String()
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	imported, err := parseGraph([]byte(json))
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	err = server.g.Update("import a CSV file", func(root *graph.Node) (*graph.Node, error) {
+		return imported, nil
+	})
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.Status(http.StatusOK)
+}
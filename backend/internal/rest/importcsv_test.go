@@ -0,0 +1,88 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_ImportCsv_Replace(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/graph/export?format=csv", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	if response.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("Unexpected content type %q", response.Header().Get("Content-Type"))
+	}
+	exported := response.Body.String()
+	csv := strings.Replace(exported, "id_F,id_D,F,", "id_F,id_D,F bis,", 1)
+	response = upload(router, "/apis/import/csv", csv)
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to import the CSV file: %d %s", response.Code, response.Body)
+		return
+	}
+	response = serve(router, http.MethodGet, "/apis/graph/print", "")
+	if !strings.Contains(response.Body.String(), "1.3.1 F bis\n") {
+		t.Errorf("Unexpected graph %s", response.Body)
+	}
+}
+
+func TestHttpServer_ImportCsv_Parent(t *testing.T) {
+	router := provisionRouter(t)
+	response := upload(router, "/apis/import/csv?parent=id_B", "id,parent_id,name\nx,,X\ny,x,Y\n")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to import the CSV file: %d %s", response.Code, response.Body)
+		return
+	}
+	n, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if n != 7 {
+		t.Errorf("Expected 7 nodes, got %d", n)
+	}
+}
+
+func TestHttpServer_ImportCsv_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	response := upload(router, "/apis/import/csv", "id,parent_id,name\n0,,ens\nb,z,B\n")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status code %d %s", response.Code, response.Body)
+		return
+	}
+	var body struct {
+		Issues []struct {
+			Path    string `json:"path"`
+			Message string `json:"message"`
+		} `json:"issues"`
+	}
+	err := json.Unmarshal(response.Body.Bytes(), &body)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(body.Issues) != 1 || body.Issues[0].Path != "row 3" {
+		t.Errorf("Unexpected issues %v", body.Issues)
+	}
+	response = upload(router, "/apis/import/csv", "id,parent_id,name\na,,A\nb,,B\n")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status code %d %s", response.Code, response.Body)
+	}
+	response = upload(router, "/apis/import/csv", "id,parent_id,name,type,ref\n0,,ens,,\nb,0,B,,\nc,b,,reference,0\n")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status code %d %s", response.Code, response.Body)
+		return
+	}
+	err = json.Unmarshal(response.Body.Bytes(), &body)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(body.Issues) != 1 || body.Issues[0].Path != "row 4" {
+		t.Errorf("Unexpected issues %v", body.Issues)
+	}
+}
@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"github.com/gin-gonic/gin"
+)
+
+// importOutline imports an uploaded outline in the Stringify, Markdown or OPML format as children of the given parent
+// node, which defaults to the root. An export of the whole graph is imported under the root rather than as a copy of it
+func (server *HttpServer) importOutline(context *gin.Context) {
+	server.importNodes(context, "an outline", graph.ImportOutline, (*graph.Node).AddOutline)
+}
@@ -0,0 +1,84 @@
+package rest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_ImportOutline_Success(t *testing.T) {
+	router := provisionRouter(t)
+	response := upload(router, "/apis/import/outline?parent=id_D", "- ens reale [division]\n  - substantia {#ff0000}\n")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to import the outline: %d %s", response.Code, response.Body)
+		return
+	}
+	response = serve(router, http.MethodGet, "/apis/graph/export?format=markdown", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	if response.Header().Get("Content-Type") != "text/markdown" {
+		t.Errorf("Unexpected content type %q", response.Header().Get("Content-Type"))
+	}
+	expected := "  - D [opposition] {#00ff00}\n    - F {#0000ff}\n    - ens reale [division]\n      - substantia {#ff0000}\n"
+	if !strings.Contains(response.Body.String(), expected) {
+		t.Errorf("Unexpected Markdown document %s", response.Body)
+	}
+}
+
+func TestHttpServer_ImportOutline_Opml(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/graph/export?format=opml", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	if response.Header().Get("Content-Type") != "text/x-opml" {
+		t.Errorf("Unexpected content type %q", response.Header().Get("Content-Type"))
+	}
+	response = upload(router, "/apis/import/outline?parent=id_B", response.Body.String())
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to import the outline: %d %s", response.Code, response.Body)
+		return
+	}
+	n, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if n != 10 {
+		t.Errorf("Expected 10 nodes, got %d", n)
+	}
+}
+
+func TestHttpServer_ImportOutline_Root(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/graph/export?format=text", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	response = upload(router, "/apis/import/outline", response.Body.String())
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to import the outline: %d %s", response.Code, response.Body)
+		return
+	}
+	response = serve(router, http.MethodGet, "/apis/graph/export?format=text", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to export the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	expected := "1 ens\n1.1 B\n1.2 C\n1.3 D\n1.3.1 F\n1.4 B\n1.5 C\n1.6 D\n1.6.1 F\n"
+	if response.Body.String() != expected {
+		t.Errorf("Unexpected outline %s", response.Body)
+	}
+}
+
+func TestHttpServer_ImportOutline_Invalid(t *testing.T) {
+	router := provisionRouter(t)
+	response := upload(router, "/apis/import/outline", "1 ens\n1.1.1 B\n")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status %d %s", response.Code, response.Body)
+	}
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// importYaml imports the sections of an uploaded YAML file, e.g., lexical-fields.yaml, as children of the given
+// parent node, which defaults to the root
+func (server *HttpServer) importYaml(context *gin.Context) {
+	server.importNodes(context, "a YAML file", graph.ImportYaml, addNodes)
+}
+
+// importNodes converts an uploaded file into nodes with the given importer and adds them to the given parent node,
+// which defaults to the root, with the given function
+func (server *HttpServer) importNodes(context *gin.Context, description string,
+	importer func(bytes []byte) ([]*graph.Node, error),
+	add func(root *graph.Node, parent string, nodes []*graph.Node) (*graph.Node, error)) {
+	parent := context.DefaultQuery("parent", "0")
+	bytes, err := readFile(context, "file")
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	nodes, err := importer(bytes)
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	operation := fmt.Sprintf("import %s into %q", description, parent)
+	err = server.g.Update(operation, func(root *graph.Node) (*graph.Node, error) {
+		return add(root, parent, nodes)
+	})
+	if err != nil {
+		msg := fmt.Sprintf(importFailed, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.Status(http.StatusOK)
+}
+
+// addNodes adds the given nodes as children of the given parent node
+func addNodes(root *graph.Node, parent string, nodes []*graph.Node) (*graph.Node, error) {
+	for _, node := range nodes {
+		_, err := root.AddNode(parent, node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
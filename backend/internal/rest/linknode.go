@@ -0,0 +1,20 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// linkNode adds a reference to a node to the children of another parent
+func (server *HttpServer) linkNode(context *gin.Context) {
+	parent := context.Param("parent")
+	target := context.Param("node")
+	err := server.g.Do(graph.NewLinkOperation(parent, target))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to link the node %q to %q [%s]", target, parent, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+	}
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// merge merges the uploaded graph into the current one, relative to the optional base graph uploaded as "base", and
+// returns the conflicts. With dryRun=true, the conflicts are returned without merging the graph
+func (server *HttpServer) merge(context *gin.Context, incoming *graph.Node) {
+	var base *graph.Node
+	if _, err := context.FormFile("base"); err == nil {
+		baseBytes, err := readFile(context, "base")
+		if err == nil {
+			base, err = parseGraph(baseBytes)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Failed to read the base graph [%s]", err)
+			log.Error(msg)
+			handleFailedRequest(context, err, msg)
+			return
+		}
+	}
+	var conflicts []graph.Conflict
+	var err error
+	if context.Query("dryRun") == "true" {
+		err = server.g.View(func(root *graph.Node) (err error) {
+			_, conflicts, err = root.Clone().Merge(incoming, base)
+			return err
+		})
+	} else {
+		err = server.g.Update("merge a graph", func(root *graph.Node) (*graph.Node, error) {
+			var err error
+			root, conflicts, err = root.Merge(incoming, base)
+			return root, err
+		})
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Failed to merge the graph [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.JSON(http.StatusOK, gin.H{
+		"conflicts": conflicts,
+	})
+}
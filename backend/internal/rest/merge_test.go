@@ -0,0 +1,44 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_Merge(t *testing.T) {
+	router := provisionRouter(t)
+	incoming := strings.Replace(testGraph, `"children":[]}]}]}`,
+		`"children":[]},{"id":"id_K","name":"K","children":[]}]}]}`, 1)
+	incoming = strings.Replace(incoming, `{"id":"id_B","name":"B","type":"lexeme","color":"#ff0000","properties":{},"children":[]},`, "", 1)
+	response := upload(router, "/apis/upload?mode=merge", incoming)
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to merge the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	var result struct {
+		Conflicts []map[string]string `json:"conflicts"`
+	}
+	err := json.Unmarshal(response.Body.Bytes(), &result)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("Unexpected conflicts %v", result.Conflicts)
+	}
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if actual != 6 {
+		t.Errorf("Expected 6 nodes, got %d", actual)
+	}
+
+	response = upload(router, "/apis/upload?mode=append", incoming)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", response.Code)
+	}
+}
@@ -1,12 +1,18 @@
 package rest
 
 import (
+	"backend/internal/graph"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
 
 // printGraph returns a simplified string representation of this graph
 func (server *HttpServer) printGraph(context *gin.Context) {
+	var s string
+	_ = server.g.View(func(root *graph.Node) error {
+		s = root.Stringify()
+		return nil
+	})
 	context.Header(contentType, textPlain)
-	context.String(http.StatusOK, server.g.Root.Stringify())
+	context.String(http.StatusOK, s)
 }
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// queryNodes returns the nodes matching the query q=<query> with their paths, at most limit=<n> of them if set
+func (server *HttpServer) queryNodes(context *gin.Context) {
+	query := context.Query("q")
+	limit, err := countParam(context, "limit")
+	if err != nil {
+		msg := fmt.Sprintf("Failed to query %q [%s]", query, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	var matches []graph.Match
+	err = server.g.View(func(root *graph.Node) (err error) {
+		matches, err = root.Query(query)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to query %q [%s]", query, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	if limit > 0 {
+		matches = matches[:min(limit, len(matches))]
+	}
+	context.JSON(http.StatusOK, matches)
+}
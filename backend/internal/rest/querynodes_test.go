@@ -0,0 +1,49 @@
+package rest_test
+
+import (
+	"backend/internal/graph"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestHttpServer_QueryNodes_Success(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/query?q="+url.QueryEscape(`hasParent(type:opposition) color="#0000ff"`), "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to query: %d %s", response.Code, response.Body)
+		return
+	}
+	var matches []graph.Match
+	err := json.Unmarshal(response.Body.Bytes(), &matches)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(matches) != 1 || matches[0].Id != "id_F" || matches[0].Number != "1.3.1" || len(matches[0].Ancestors) != 2 ||
+		matches[0].Ancestors[1].Id != "id_D" {
+		t.Errorf("Unexpected matches %s", response.Body)
+		return
+	}
+
+	response = serve(router, http.MethodGet, "/apis/query?q=depth<2&limit=2", "")
+	err = json.Unmarshal(response.Body.Bytes(), &matches)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(matches) != 2 || matches[0].Id != "0" || matches[1].Id != "id_B" {
+		t.Errorf("Unexpected matches %s", response.Body)
+	}
+}
+
+func TestHttpServer_QueryNodes_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	for _, path := range []string{"/apis/query", "/apis/query?q=colour:red", "/apis/query?q=ens&limit=-1"} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("Unexpected status code for %s: %d %s", path, response.Code, response.Body)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// redo applies again the latest undone operation and returns the resulting graph
+func (server *HttpServer) redo(context *gin.Context) {
+	err := server.g.Redo()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to redo the latest undone operation [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	server.writeGraph(context)
+}
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// renderGraph renders the whole graph as an SVG image
+func (server *HttpServer) renderGraph(context *gin.Context) {
+	var bytes []byte
+	_ = server.g.View(func(root *graph.Node) error {
+		bytes = root.ToSvg()
+		return nil
+	})
+	context.Data(http.StatusOK, imageSvg, bytes)
+}
+
+// renderNode renders the subtree of the given node as an SVG image
+func (server *HttpServer) renderNode(context *gin.Context) {
+	id := context.Param("node")
+	var bytes []byte
+	err := server.g.ViewNode(id, func(_, node *graph.Node) error {
+		bytes = node.ToSvg()
+		return nil
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to render the node %q [%s]", id, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.Data(http.StatusOK, imageSvg, bytes)
+}
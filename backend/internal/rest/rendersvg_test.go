@@ -0,0 +1,28 @@
+package rest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_RenderSvg(t *testing.T) {
+	router := provisionRouter(t)
+	for _, path := range []string{"/apis/graph/svg", "/apis/nodes/id_D/svg"} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != http.StatusOK {
+			t.Errorf("Failed to render %s: %d %s", path, response.Code, response.Body)
+			return
+		}
+		if response.Header().Get("Content-Type") != "image/svg+xml" {
+			t.Errorf("Unexpected content type %q", response.Header().Get("Content-Type"))
+		}
+		if !strings.HasPrefix(response.Body.String(), "<svg ") || !strings.Contains(response.Body.String(), ">F</text>") {
+			t.Errorf("Unexpected SVG document %s", response.Body)
+		}
+	}
+	response := serve(router, http.MethodGet, "/apis/nodes/id_Z/svg", "")
+	if response.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", response.Code)
+	}
+}
@@ -0,0 +1,26 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// restoreRevision replaces the graph with the one at the given revision and returns it
+func (server *HttpServer) restoreRevision(context *gin.Context) {
+	revision, err := revisionParam(context)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to restore the revision [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	err = server.g.Restore(revision)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to restore the revision %d [%s]", revision, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	server.writeGraph(context)
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+// defaultSearchLimit is the maximum number of hits returned unless the request sets another limit
+const defaultSearchLimit = 50
+
+// searchNodes returns the nodes matching q=<query>, best matches first, at most limit=<n> of them
+func (server *HttpServer) searchNodes(context *gin.Context) {
+	query := context.Query("q")
+	limit := defaultSearchLimit
+	if param := context.Query("limit"); param != "" {
+		var err error
+		limit, err = strconv.Atoi(param)
+		if err != nil || limit <= 0 {
+			msg := fmt.Sprintf("Invalid limit %q", param)
+			log.Error(msg)
+			handleFailedRequest(context, errors.NewIllegalArgumentError(msg), msg)
+			return
+		}
+	}
+	var hits []graph.Hit
+	err := server.g.View(func(root *graph.Node) (err error) {
+		hits, err = root.Search(query)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to search for %q [%s]", query, err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	context.JSON(http.StatusOK, hits[:min(limit, len(hits))])
+}
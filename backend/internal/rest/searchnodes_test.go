@@ -0,0 +1,37 @@
+package rest_test
+
+import (
+	"backend/internal/graph"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_SearchNodes_Success(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodGet, "/apis/search?q=f", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to search: %d %s", response.Code, response.Body)
+		return
+	}
+	var hits []graph.Hit
+	err := json.Unmarshal(response.Body.Bytes(), &hits)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(hits) != 1 || hits[0].Id != "id_F" || hits[0].Number != "1.3.1" || len(hits[0].Ancestors) != 2 ||
+		hits[0].Ancestors[1].Id != "id_D" {
+		t.Errorf("Unexpected hits %s", response.Body)
+	}
+}
+
+func TestHttpServer_SearchNodes_Failure(t *testing.T) {
+	router := provisionRouter(t)
+	for _, path := range []string{"/apis/search", "/apis/search?q=ens&limit=0"} {
+		response := serve(router, http.MethodGet, path, "")
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("Unexpected status code for %s: %d %s", path, response.Code, response.Body)
+		}
+	}
+}
@@ -11,23 +11,37 @@ import (
 )
 
 const (
-	address         = ":8080"
-	applicationJson = "application/json"
-	contentType     = "Content-Type"
-	maxMem          = 1 << 16
-	textPlain       = "text/plain"
-	uploadFailed    = "Upload failed [%s]"
+	address            = ":8080"
+	applicationJson    = "application/json"
+	contentDisposition = "Content-Disposition"
+	contentType        = "Content-Type"
+	imageSvg           = "image/svg+xml"
+	importFailed       = "Import failed [%s]"
+	maxMem             = 1 << 16
+	textPlain          = "text/plain"
+	uploadFailed       = "Upload failed [%s]"
 )
 
 type HttpServer struct {
 	g *graph.Graph
 }
 
+// NewHttpServer creates a new http server
 func NewHttpServer(g *graph.Graph) *HttpServer {
 	return &HttpServer{g: g}
 }
 
+// StartHttpServer starts the http server
 func (server *HttpServer) StartHttpServer() error {
+	err := server.Router().Run(address)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Router returns the router handling the backend's APIs
+func (server *HttpServer) Router() *gin.Engine {
 	router := gin.Default()
 	router.HandleMethodNotAllowed = true
 	router.MaxMultipartMemory = maxMem
@@ -38,19 +52,34 @@ func (server *HttpServer) StartHttpServer() error {
 
 	router.DELETE("/apis/graph", server.deleteGraph)
 	router.GET("/apis/graph", server.getGraph)
+	router.POST("/apis/graph/diff", server.diffGraph)
+	router.GET("/apis/graph/export", server.getGraph)
+	router.GET("/apis/graph/layout", server.getLayout)
 	router.GET("/apis/graph/print", server.printGraph)
+	router.GET("/apis/graph/svg", server.renderGraph)
+	router.GET("/apis/history", server.getHistory)
+	router.GET("/apis/history/:rev", server.getRevision)
+	router.GET("/apis/history/:rev/diff", server.diffRevision)
+	router.POST("/apis/history/:rev/restore", server.restoreRevision)
+	router.POST("/apis/import/csv", server.importCsv)
+	router.POST("/apis/import/outline", server.importOutline)
+	router.POST("/apis/import/yaml", server.importYaml)
 	router.PUT("/apis/nodes", server.addChildToRootNode)
+	router.GET("/apis/nodes/:node", server.getNode)
+	router.GET("/apis/nodes/:node/latex", server.exportNode)
+	router.GET("/apis/nodes/:node/path", server.findPath)
+	router.GET("/apis/nodes/:node/svg", server.renderNode)
 	router.GET("/apis/nodes/:node/targets", server.findTargets)
 	router.PUT("/apis/nodes/:parent", server.updateNode)
+	router.PUT("/apis/nodes/:parent/:node", server.linkNode)
 	router.DELETE("/apis/nodes/:parent/:node", server.deleteNode)
 	router.POST("/apis/nodes/:parent/:node/:newParent", server.moveNode)
+	router.GET("/apis/query", server.queryNodes)
+	router.POST("/apis/redo", server.redo)
+	router.GET("/apis/search", server.searchNodes)
+	router.POST("/apis/undo", server.undo)
 	router.POST("/apis/upload", server.upload)
-
-	err := router.Run(address)
-	if err != nil {
-		return err
-	}
-	return nil
+	return router
 }
 
 // healthCheck returns a "200 OK" response to indicate that the backend service is available
@@ -60,15 +89,18 @@ func healthCheck(context *gin.Context) {
 	})
 }
 
-// handleFailedRequest writes a response with the given error code and message
+// handleFailedRequest writes a response with the given error code and message. The issues of a validation error are
+// listed as well
 func handleFailedRequest(context *gin.Context, err error, message string) {
 
 	var duplicatedNodeError *graphErrors.DuplicatedNodeError
 	var illegalArgumentError *graphErrors.IllegalArgumentError
 	var nodeNotFoundError *graphErrors.NodeNotFoundError
+	var validationError *graphErrors.ValidationError
 
 	var statusCode int
-	if errors.As(err, &duplicatedNodeError) || errors.As(err, &illegalArgumentError) {
+	if errors.As(err, &duplicatedNodeError) || errors.As(err, &illegalArgumentError) ||
+		errors.As(err, &validationError) {
 		statusCode = http.StatusBadRequest
 	} else if errors.As(err, &nodeNotFoundError) {
 		statusCode = http.StatusNotFound
@@ -76,8 +108,12 @@ func handleFailedRequest(context *gin.Context, err error, message string) {
 		statusCode = http.StatusInternalServerError
 	}
 
-	context.JSON(statusCode, gin.H{
+	response := gin.H{
 		"status":  fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
 		"message": message,
-	})
+	}
+	if validationError != nil {
+		response["issues"] = validationError.Issues()
+	}
+	context.JSON(statusCode, response)
 }
@@ -0,0 +1,163 @@
+package rest_test
+
+import (
+	"backend/internal/graph"
+	"backend/internal/rest"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// The tests of this file are meant to be run with the race detector, i.e., go test -race ./...
+
+const testGraph = `{"id":"0","name":"ens","type":"lexeme","color":"#dddddd","properties":{},"children":[
+{"id":"id_B","name":"B","type":"lexeme","color":"#ff0000","properties":{},"children":[]},
+{"id":"id_C","name":"C","type":"lexeme","color":"#ff0000","properties":{},"children":[]},
+{"id":"id_D","name":"D","type":"opposition","color":"#00ff00","properties":{},"children":[
+{"id":"id_F","name":"F","type":"lexeme","color":"#0000ff","properties":{},"children":[]}]}]}`
+
+func provisionRouter(t *testing.T) http.Handler {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	g, err := graph.NewGraph("ens", filepath.Join(t.TempDir(), "graph.json"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	router := rest.NewHttpServer(g).Router()
+	response := upload(router, "/apis/upload", testGraph)
+	if response.Code != http.StatusOK {
+		t.Fatalf("Failed to upload the graph: %d %s", response.Code, response.Body)
+	}
+	return router
+}
+
+func serve(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func upload(router http.Handler, path, content string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "graph.json")
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+	request := httptest.NewRequest(http.MethodPost, path, &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func count(router http.Handler) (int, error) {
+	response := serve(router, http.MethodGet, "/apis/graph", "")
+	var root graph.Node
+	err := json.Unmarshal(response.Body.Bytes(), &root)
+	if err != nil {
+		return 0, err
+	}
+	return len(root.Traverse()), nil
+}
+
+func TestHttpServer_ConcurrentRequests(t *testing.T) {
+	router := provisionRouter(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(5)
+		go func(i int) {
+			defer wg.Done()
+			node := fmt.Sprintf(`{"id":"id_%d","name":"%d","color":"#ff0000","type":"lexeme","children":null}`, i, i)
+			response := serve(router, http.MethodPut, "/apis/nodes", node)
+			if response.Code != http.StatusOK {
+				t.Errorf("Failed to add the node %d: %d %s", i, response.Code, response.Body)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			node := fmt.Sprintf(`{"id":"id_F","name":"F","color":"#ff0000","type":"lexeme","children":[{"id":"id_F_%d","name":"F%d"}]}`, i, i)
+			response := serve(router, http.MethodPut, "/apis/nodes/id_D", node)
+			if response.Code != http.StatusOK {
+				t.Errorf("Failed to update the node F: %d %s", response.Code, response.Body)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			path := "/apis/nodes/id_B/id_C/0"
+			if i%2 == 0 {
+				path = "/apis/nodes/0/id_C/id_B"
+			}
+			serve(router, http.MethodPost, path, "")
+		}(i)
+		go func() {
+			defer wg.Done()
+			for _, path := range []string{"/apis/graph", "/apis/graph/print", "/apis/nodes/id_F/targets", "/apis/graph/export?format=yaml"} {
+				response := serve(router, http.MethodGet, path, "")
+				if response.Code != http.StatusOK {
+					t.Errorf("GET %s failed: %d %s", path, response.Code, response.Body)
+				}
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			serve(router, http.MethodDelete, fmt.Sprintf("/apis/nodes/id_F/id_F_%d", i), "")
+		}(i)
+	}
+	wg.Wait()
+
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	// the 20 added nodes and between 0 and 20 children of node F
+	if actual < 25 || actual > 45 {
+		t.Errorf("Unexpected number of nodes %d", actual)
+	}
+}
+
+func TestHttpServer_ConcurrentUploads(t *testing.T) {
+	router := provisionRouter(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			response := upload(router, "/apis/upload", testGraph)
+			if response.Code != http.StatusOK {
+				t.Errorf("Failed to upload the graph: %d %s", response.Code, response.Body)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			serve(router, http.MethodDelete, "/apis/graph", "")
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := count(router)
+			if err != nil {
+				t.Errorf(err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if actual != 1 && actual != 5 {
+		t.Errorf("Unexpected number of nodes %d", actual)
+	}
+}
@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// undo reverts the latest operation and returns the resulting graph
+func (server *HttpServer) undo(context *gin.Context) {
+	err := server.g.Undo()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to undo the latest operation [%s]", err)
+		log.Error(msg)
+		handleFailedRequest(context, err, msg)
+		return
+	}
+	server.writeGraph(context)
+}
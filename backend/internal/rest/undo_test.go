@@ -0,0 +1,55 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestHttpServer_UndoRedo(t *testing.T) {
+	router := provisionRouter(t)
+	response := serve(router, http.MethodDelete, "/apis/nodes/0/id_D", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to delete the node: %d %s", response.Code, response.Body)
+		return
+	}
+
+	response = serve(router, http.MethodPost, "/apis/undo", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to undo: %d %s", response.Code, response.Body)
+		return
+	}
+	var root struct {
+		Children []struct {
+			Id string `json:"id"`
+		} `json:"children"`
+	}
+	err := json.Unmarshal(response.Body.Bytes(), &root)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if len(root.Children) != 3 || root.Children[2].Id != "id_D" {
+		t.Errorf("The node was not restored at its original index: %s", response.Body)
+		return
+	}
+
+	response = serve(router, http.MethodPost, "/apis/redo", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to redo: %d %s", response.Code, response.Body)
+		return
+	}
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if actual != 3 {
+		t.Errorf("Expected 3 nodes, got %d", actual)
+	}
+
+	response = serve(router, http.MethodPost, "/apis/redo", "")
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", response.Code)
+	}
+}
@@ -18,13 +18,10 @@ func (server *HttpServer) updateNode(context *gin.Context) {
 		handleFailedRequest(context, err, msg)
 		return
 	}
-	root, err := server.g.Root.UpdateNode(parent, node)
+	err = server.g.Do(graph.NewUpdateOperation(parent, node))
 	if err != nil {
 		msg := fmt.Sprintf("Failed to add the node %q to its parent %q [%s]", node.Name, parent, err)
 		log.Error(msg)
 		handleFailedRequest(context, err, msg)
-		return
 	}
-	server.g.Root = root
-	server.g.Save()
 }
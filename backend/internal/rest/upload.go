@@ -1,47 +1,87 @@
 package rest
 
 import (
+	"backend/internal/graph"
+	"backend/internal/graph/errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
+	"io"
 	"net/http"
 )
 
-// upload uploads a graph
+// upload uploads a graph, which replaces the current one unless mode=merge. The graph is validated first, and only
+// validated with dryRun=true
 func (server *HttpServer) upload(context *gin.Context) {
-	fh, err := context.FormFile("file")
+	bytes, err := readFile(context, "file")
 	if err != nil {
 		msg := fmt.Sprintf(uploadFailed, err)
 		log.Error(msg)
 		handleFailedRequest(context, err, msg)
 		return
 	}
-	log.Debug("File uploaded")
-	file, err := fh.Open()
-	if err != nil {
-		msg := fmt.Sprintf(uploadFailed, err)
+	mode := context.DefaultQuery("mode", "replace")
+	if mode != "replace" && mode != "merge" {
+		msg := fmt.Sprintf("Unsupported upload mode %q", mode)
 		log.Error(msg)
-		handleFailedRequest(context, err, msg)
+		handleFailedRequest(context, errors.NewIllegalArgumentError(msg), msg)
 		return
 	}
-	defer file.Close()
-
-	bytes := make([]byte, fh.Size)
-	n, err := file.Read(bytes)
+	uploaded, err := parseGraph(bytes)
 	if err != nil {
 		msg := fmt.Sprintf(uploadFailed, err)
 		log.Error(msg)
 		handleFailedRequest(context, err, msg)
 		return
 	}
-	log.Debugf("Read %d bytes", n)
-	server.g.Root, err = server.g.Root.Parse(bytes)
+	if mode == "merge" {
+		server.merge(context, uploaded)
+		return
+	}
+	if context.Query("dryRun") == "true" {
+		context.JSON(http.StatusOK, gin.H{
+			"issues": make([]any, 0),
+		})
+		return
+	}
+	err = server.g.Update("upload a graph", func(root *graph.Node) (*graph.Node, error) {
+		return uploaded, nil
+	})
 	if err != nil {
 		msg := fmt.Sprintf(uploadFailed, err)
 		log.Error(msg)
 		handleFailedRequest(context, err, msg)
 		return
 	}
-	server.g.Save()
 	context.Status(http.StatusOK)
 }
+
+// parseGraph validates and parses an uploaded graph
+func parseGraph(bytes []byte) (*graph.Node, error) {
+	err := graph.Validate(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return new(graph.Node).Parse(bytes)
+}
+
+// readFile reads the content of the file uploaded as the given form field
+func readFile(context *gin.Context, field string) ([]byte, error) {
+	fh, err := context.FormFile(field)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("File uploaded")
+	file, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	bytes, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Read %d bytes", len(bytes))
+	return bytes, nil
+}
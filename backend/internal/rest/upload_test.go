@@ -0,0 +1,49 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHttpServer_Upload_FailsValidation(t *testing.T) {
+	router := provisionRouter(t)
+	invalid := strings.Replace(testGraph, `"id":"id_C"`, `"id":"id_B"`, 1)
+	invalid = strings.Replace(invalid, `"type":"opposition"`, `"type":"genus"`, 1)
+	for _, path := range []string{"/apis/upload", "/apis/upload?dryRun=true"} {
+		response := upload(router, path, invalid)
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", response.Code)
+			return
+		}
+		var result struct {
+			Issues []map[string]string `json:"issues"`
+		}
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+		if len(result.Issues) != 2 || result.Issues[0]["path"] != "$.children[1].id" {
+			t.Errorf("Unexpected issues %v", result.Issues)
+		}
+	}
+}
+
+func TestHttpServer_Upload_DryRun(t *testing.T) {
+	router := provisionRouter(t)
+	response := upload(router, "/apis/upload?dryRun=true", `{"id":"0","name":"ens","children":[]}`)
+	if response.Code != http.StatusOK {
+		t.Errorf("Failed to validate the graph: %d %s", response.Code, response.Body)
+		return
+	}
+	actual, err := count(router)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	if actual != 5 {
+		t.Errorf("Expected 5 nodes, got %d", actual)
+	}
+}
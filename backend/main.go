@@ -5,9 +5,15 @@ import (
 	"backend/internal/rest"
 	_ "embed"
 	log "github.com/sirupsen/logrus"
+	"os"
+	"strconv"
 )
 
-const filename = "volume/graph.json"
+const (
+	backupsEnv = "BACKUPS"
+	filename   = "volume/graph.json"
+	historyEnv = "HISTORY"
+)
 
 // main starts the backend http server
 func main() {
@@ -15,6 +21,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create the root node [%v]", err)
 	}
+	readIntEnv(backupsEnv, &g.Backups)
+	readIntEnv(historyEnv, &g.History)
 	g.Load()
 
 	server := rest.NewHttpServer(g)
@@ -23,3 +31,16 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 }
+
+// readIntEnv reads an integer from the given environment variable, if it is set
+func readIntEnv(name string, value *int) {
+	s, found := os.LookupEnv(name)
+	if !found {
+		return
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		log.Fatalf("Invalid value %q of %s [%v]", s, name, err)
+	}
+	*value = i
+}
@@ -224,5 +224,22 @@ if [ "$VAR" != "C" ]; then
 fi
 echo "Moved node C to the root"
 
+#######################################################################################################################
+
+echo
+echo
+echo "Importing the lexical fields"
+response=$(curl -s -w "%{http_code}" -F file=@../lexical-fields.yaml \
+  -X POST http://localhost:8080/apis/import/yaml --output output.json)
+if [ $response != 200 ]; then
+  tearDown 1 "Failed to import the lexical fields"
+fi
+
+VAR=$(curl -s http://localhost:8080/apis/graph | jq  -r '.children[2].name')
+if [ "$VAR" != "accidentale-substantiale" ]; then
+  tearDown 1 "Failed to import the lexical fields"
+fi
+echo "Imported the lexical fields"
+
 tearDown 0 "test cases succeeded"
 